@@ -15,7 +15,13 @@ import (
 
 	"github.com/DIMO-Network/meta-transaction-processor/internal/config"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/consumer"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/entrypoint"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/forwarder"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/gaslimit"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/gasprice"
 	appmetrics "github.com/DIMO-Network/meta-transaction-processor/internal/metrics"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/policy"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/quota"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/rpc"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/sender"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
@@ -39,7 +45,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 )
 
@@ -98,70 +103,158 @@ func main() {
 	pdb := db.NewDbConnectionFromSettings(ctx, &settings.DB, true)
 	pdb.WaitForDB(logger)
 
-	confirmationBlocks := big.NewInt(settings.ConfirmationBlocks)
-
-	boostAfterBlocks := big.NewInt(settings.BoostAfterBlocks)
-
-	senders, err := createSenders(ctx, &settings, &logger)
-	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to create sender.")
-	}
-
-	ethClient, err := ethclient.Dial(settings.EthereumRPCURL)
-	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to create Ethereum client.")
-	}
-
 	kafkaClient, err := createKafka(&settings)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create Kafka client.")
 	}
 
-	sprod, err := status.NewKafka(ctx, settings.TransactionStatusTopic, kafkaClient, &logger)
+	sprod := status.NewOutbox()
+
+	relay, err := status.NewRelay(settings.TransactionStatusTopic, kafkaClient, pdb, &logger)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create Kafka transaction status producer.")
 	}
 
-	chainID, err := ethClient.ChainID(ctx)
+	quotas, err := quota.New(settings.ClientRequestsPerMinute, settings.ClientDailyGasBudget, settings.ClientQuotaOverrides)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("Couldn't retrieve chain id.")
+		logger.Fatal().Err(err).Msg("Invalid client quotas.")
 	}
 
-	logger.Info().Msgf("Chain id is %d.", chainID)
+	var tickerGroup sync.WaitGroup
 
+	tickerGroup.Add(1)
 	go func() {
-		err := consumer.New(ctx, "meta-transaction-processor", settings.TransactionRequestTopic, kafkaClient, &logger, pdb, len(senders))
+		defer tickerGroup.Done()
+		relay.Run(ctx)
+	}()
+
+	var chains []*consumer.Chain
+
+	for _, chainSettings := range settings.AllChains() {
+		ethClient, err := ethclient.Dial(chainSettings.EthereumRPCURL)
 		if err != nil {
-			logger.Fatal().Err(err).Msg("Failed to create Kafka consumer.")
+			logger.Fatal().Err(err).Msg("Failed to create Ethereum client.")
 		}
-	}()
 
-	var tickerGroup sync.WaitGroup
+		chainID, err := ethClient.ChainID(ctx)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Couldn't retrieve chain id.")
+		}
+
+		for _, ch := range chains {
+			if ch.ID.Cmp(chainID) == 0 {
+				logger.Fatal().Msgf("Chain id %d is configured more than once.", chainID)
+			}
+		}
+
+		logger.Info().Msgf("Chain id is %d.", chainID)
+
+		if chainSettings.BlockTime <= 0 || chainSettings.ConfirmationBlocks <= 0 || chainSettings.BoostAfterBlocks <= 0 {
+			logger.Fatal().Msgf("Chain %d needs a positive BLOCK_TIME, CONFIRMATION_BLOCKS and BOOST_AFTER_BLOCKS.", chainID)
+		}
+
+		chainLogger := logger.With().Str("chainId", chainID.String()).Logger()
+
+		senders, err := createSenders(ctx, &settings, &chainSettings, &chainLogger)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to create sender.")
+		}
+
+		var fwd *forwarder.Forwarder
+		if chainSettings.ForwarderAddress != "" {
+			if !common.IsHexAddress(chainSettings.ForwarderAddress) {
+				logger.Fatal().Msgf("Invalid forwarder address %q.", chainSettings.ForwarderAddress)
+			}
+			fwd = forwarder.New(common.HexToAddress(chainSettings.ForwarderAddress), chainSettings.ForwarderName, chainID, ethClient)
+		}
+
+		var ep *entrypoint.EntryPoint
+		if chainSettings.EntryPointAddress != "" {
+			if !common.IsHexAddress(chainSettings.EntryPointAddress) {
+				logger.Fatal().Msgf("Invalid EntryPoint address %q.", chainSettings.EntryPointAddress)
+			}
+			// Any of our wallets will do for simulation.
+			ep = entrypoint.New(common.HexToAddress(chainSettings.EntryPointAddress), ethClient, senders[0].Address())
+		}
+
+		pol, err := policy.New(chainSettings.PolicyAllowedContracts, chainSettings.PolicyAllowedSelectors, chainSettings.PolicyMaxCalldataBytes, chainSettings.PolicyMaxValue, chainSettings.PolicyMaxGasLimit, chainSettings.PolicyAllowDeployments)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid request policy.")
+		}
+
+		chains = append(chains, &consumer.Chain{ID: chainID, NumWallets: len(senders), Forwarder: fwd, EntryPoint: ep, Policy: pol})
+
+		confirmationBlocks := big.NewInt(chainSettings.ConfirmationBlocks)
+
+		if !ticker.ValidFinality(chainSettings.FinalityTag) {
+			logger.Fatal().Msgf("Invalid finality tag %q.", chainSettings.FinalityTag)
+		}
+
+		boostAfterBlocks := big.NewInt(chainSettings.BoostAfterBlocks)
 
-	for i, sender := range senders {
-		watcher := ticker.New(&logger, sprod, confirmationBlocks, boostAfterBlocks, pdb, ethClient, chainID, sender, i, settings.DisableBoosting)
-
-		tickerGroup.Add(1)
-
-		go func() {
-			defer tickerGroup.Done()
-			ticker := time.NewTicker(time.Duration(settings.BlockTime) * time.Second)
-			for {
-				select {
-				case <-ticker.C:
-					appmetrics.TicksTotal.Inc()
-					if err := watcher.Tick(ctx); err != nil {
-						appmetrics.TickErrorsTotal.With(prometheus.Labels{"walletIndex": strconv.Itoa(i)}).Inc()
-						log.Err(err).Msg("Error during tick.")
+		escalation, err := ticker.ParseEscalation(chainSettings.EscalationSchedule)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid escalation schedule.")
+		}
+
+		gasPrices, err := gasprice.Parse(ethClient, chainSettings.GasPriceStrategy, chainSettings.GasPricePriorityStrategies, chainSettings.GasStationURL, chainSettings.GasPriceFloor, chainSettings.GasPriceCap)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid gas price settings.")
+		}
+
+		var maxGasPrice *big.Int
+		if chainSettings.MaxGasPrice != "" {
+			var ok bool
+			maxGasPrice, ok = new(big.Int).SetString(chainSettings.MaxGasPrice, 10)
+			if !ok || maxGasPrice.Sign() <= 0 {
+				logger.Fatal().Msgf("Invalid maximum gas price %q.", chainSettings.MaxGasPrice)
+			}
+		}
+
+		gasLimits, err := gaslimit.Parse(chainSettings.GasLimitPolicies, chainID.Int64())
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid gas limit policies.")
+		}
+
+		batching, err := ticker.NewBatchConfig(chainSettings.MulticallAddress, chainSettings.BatchContracts, chainSettings.BatchMaxSize)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid batching settings.")
+		}
+
+		defaultChain := len(chains) == 1
+
+		for i, sender := range senders {
+			watcher := ticker.New(&chainLogger, sprod, confirmationBlocks, chainSettings.FinalityTag, boostAfterBlocks, gasPrices, escalation, gasLimits, chainSettings.SimulateTransactions, chainSettings.AccessLists, maxGasPrice, pdb, ethClient, chainID, sender, i, defaultChain, chainSettings.DisableBoosting, quotas, time.Duration(settings.PriorityAgingSeconds)*time.Second, batching, chainSettings.BundleMaxSize)
+
+			tickerGroup.Add(1)
+
+			go func() {
+				defer tickerGroup.Done()
+				ticker := time.NewTicker(time.Duration(chainSettings.BlockTime) * time.Second)
+				for {
+					select {
+					case <-ticker.C:
+						appmetrics.TicksTotal.Inc()
+						if err := watcher.Tick(ctx); err != nil {
+							appmetrics.TickErrorsTotal.With(prometheus.Labels{"chain": chainID.String(), "walletIndex": strconv.Itoa(i)}).Inc()
+							chainLogger.Err(err).Msg("Error during tick.")
+						}
+					case <-ctx.Done():
+						ticker.Stop()
+						return
 					}
-				case <-ctx.Done():
-					ticker.Stop()
-					return
 				}
-			}
-		}()
+			}()
+		}
 	}
 
+	go func() {
+		err := consumer.New(ctx, "meta-transaction-processor", settings.TransactionRequestTopic, settings.TransactionRequestDeadLetterTopic, kafkaClient, &logger, pdb, chains, sprod, quotas)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to create Kafka consumer.")
+		}
+	}()
+
 	go startGRPCServer(&settings, &logger, pdb)
 
 	monApp := serveMonitoring(settings.MonitoringPort, &logger)
@@ -180,11 +273,11 @@ func main() {
 	tickerGroup.Wait()
 }
 
-func createSenders(ctx context.Context, settings *config.Settings, logger *zerolog.Logger) ([]sender.Sender, error) {
+func createSenders(ctx context.Context, settings *config.Settings, chainSettings *config.ChainSettings, logger *zerolog.Logger) ([]sender.Sender, error) {
 	if settings.PrivateKeyMode {
 		logger.Warn().Msg("Using injected private keys. Never do this in production.")
 
-		rawPKs := strings.Split(settings.SenderPrivateKeys, ",")
+		rawPKs := strings.Split(chainSettings.SenderPrivateKeys, ",")
 		senders := make([]sender.Sender, len(rawPKs))
 
 		for i, pk := range rawPKs {
@@ -203,7 +296,7 @@ func createSenders(ctx context.Context, settings *config.Settings, logger *zerol
 			return nil, err
 		}
 
-		keyIDs := strings.Split(settings.KMSKeyIDs, ",")
+		keyIDs := strings.Split(chainSettings.KMSKeyIDs, ",")
 		senders := make([]sender.Sender, len(keyIDs))
 
 		for i, keyID := range keyIDs {
@@ -241,6 +334,12 @@ func createKafka(settings *config.Settings) (sarama.Client, error) {
 	kafkaConfig.Producer.Partitioner = kafkautil.NewJVMCompatiblePartitioner // Use the murmur2 hash from the official client.
 	kafkaConfig.Producer.Return.Successes = true                             // Synchronous producer.
 
+	// Status events are relayed from the outbox with retries. Idempotence keeps
+	// those retries from writing duplicates to the topic.
+	kafkaConfig.Producer.Idempotent = true
+	kafkaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	kafkaConfig.Net.MaxOpenRequests = 1
+
 	return sarama.NewClient(strings.Split(settings.KafkaServers, ","), kafkaConfig)
 }
 
@@ -23,6 +23,11 @@ type Settings struct {
 	// places updates about requested transactions.
 	TransactionStatusTopic string `yaml:"TRANSACTION_STATUS_TOPIC"`
 
+	// TransactionRequestDeadLetterTopic is the name of the topic onto which the
+	// service forwards requests that it can't parse or that fail validation.
+	// If empty, such requests are only logged.
+	TransactionRequestDeadLetterTopic string `yaml:"TRANSACTION_REQUEST_DEAD_LETTER_TOPIC"`
+
 	// EthereumRPCURL is the URL of the JSON-RPC endpoint to use for
 	// blockchain interactions.
 	EthereumRPCURL string `yaml:"ETHEREUM_RPC_URL"`
@@ -51,8 +56,44 @@ type Settings struct {
 	// transaction confirmed.
 	ConfirmationBlocks int64 `yaml:"CONFIRMATION_BLOCKS"`
 
+	// FinalityTag is "finalized" or "safe" to confirm transactions once their
+	// block is covered by that block tag, instead of counting confirmations.
+	FinalityTag string `yaml:"FINALITY_TAG"`
+
 	BoostAfterBlocks int64 `yaml:"BOOST_AFTER_BLOCKS"`
 
+	// EscalationSchedule replaces BoostAfterBlocks with a list of steps like
+	// 10:+15%,30:+50%,60:cap=50000000000,200:cancel, counted in blocks since
+	// the transaction was first submitted.
+	EscalationSchedule string `yaml:"ESCALATION_SCHEDULE"`
+
+	// GasPriceStrategy is how we price transactions: node:percent,
+	// feehistory:percentile:blocks or gasstation:speed.
+	GasPriceStrategy string `yaml:"GAS_PRICE_STRATEGY"`
+
+	// GasPricePriorityStrategies is a comma-separated list of entries of the
+	// form priority=strategy. Requests at or above a listed priority use the
+	// strategy for the highest one.
+	GasPricePriorityStrategies string `yaml:"GAS_PRICE_PRIORITY_STRATEGIES"`
+
+	// GasStationURL is the Polygon gas station used by the gasstation strategy.
+	GasStationURL string `yaml:"GAS_STATION_URL"`
+
+	// GasPriceFloor and GasPriceCap bound the prices from every strategy, in
+	// wei, as decimal strings. Empty means no bound.
+	GasPriceFloor string `yaml:"GAS_PRICE_FLOOR"`
+	GasPriceCap   string `yaml:"GAS_PRICE_CAP"`
+
+	// MaxGasPrice is the most wei per gas we'll pay, as a decimal string.
+	// Unlike the cap, requests wait rather than going out at this price when
+	// the market is above it. Empty means no limit.
+	MaxGasPrice string `yaml:"MAX_GAS_PRICE"`
+
+	// GasLimitPolicies is a comma-separated list of entries of the form
+	// target=actions that override how estimates become gas limits. See
+	// gaslimit.Parse.
+	GasLimitPolicies string `yaml:"GAS_LIMIT_POLICIES"`
+
 	DB db.Settings `yaml:"DB"`
 
 	// Average block time, in seconds.
@@ -64,4 +105,193 @@ type Settings struct {
 	AWSRegion   string `yaml:"AWS_REGION"`
 
 	DisableBoosting bool `yaml:"DISABLE_BOOSTING"`
+
+	// SimulateTransactions traces every transaction for a single request
+	// before it's sent, not just those with an expectation.
+	SimulateTransactions bool `yaml:"SIMULATE_TRANSACTIONS"`
+
+	// AccessLists attaches an EIP-2930 access list to transactions when it
+	// lowers the gas estimate.
+	AccessLists bool `yaml:"ACCESS_LISTS"`
+
+	// PolicyAllowedContracts is a comma-separated list of the contracts that
+	// requests may call. If empty, any contract is allowed.
+	PolicyAllowedContracts string `yaml:"POLICY_ALLOWED_CONTRACTS"`
+
+	// PolicyAllowedSelectors is a comma-separated list of contract:selector
+	// pairs. A contract listed here can only be called with the listed function
+	// selectors.
+	PolicyAllowedSelectors string `yaml:"POLICY_ALLOWED_SELECTORS"`
+
+	// PolicyMaxCalldataBytes is the maximum length of request data. Zero means
+	// no limit.
+	PolicyMaxCalldataBytes int `yaml:"POLICY_MAX_CALLDATA_BYTES"`
+
+	// PolicyMaxValue is the most wei a request may send along with its call,
+	// as a decimal string. Empty means no limit.
+	PolicyMaxValue string `yaml:"POLICY_MAX_VALUE"`
+
+	// PolicyMaxGasLimit is the largest gas limit a request may ask for. Zero
+	// means no limit.
+	PolicyMaxGasLimit int `yaml:"POLICY_MAX_GAS_LIMIT"`
+
+	// PolicyAllowDeployments allows contract deployments when there is a
+	// contract allowlist. Without one, deployments are always allowed.
+	PolicyAllowDeployments bool `yaml:"POLICY_ALLOW_DEPLOYMENTS"`
+
+	// ClientRequestsPerMinute is the default number of requests a client may
+	// send per minute. Zero means no limit. Clients are identified by the
+	// clientId field of the request, or else the CloudEvent source.
+	ClientRequestsPerMinute int `yaml:"CLIENT_REQUESTS_PER_MINUTE"`
+
+	// ClientDailyGasBudget is the default amount of wei that a client's
+	// transactions may spend each UTC day, as a decimal string. Empty means no
+	// limit.
+	ClientDailyGasBudget string `yaml:"CLIENT_DAILY_GAS_BUDGET"`
+
+	// ClientQuotaOverrides is a comma-separated list of per-client quotas, of
+	// the form client=requestsPerMinute:dailyGasBudget. Either limit may be left
+	// blank to use the default.
+	ClientQuotaOverrides string `yaml:"CLIENT_QUOTA_OVERRIDES"`
+
+	// PriorityAgingSeconds is how long a request has to wait to gain a point of
+	// priority. Zero turns aging off, so that lower-priority requests only go
+	// out when nothing more important is waiting.
+	PriorityAgingSeconds int `yaml:"PRIORITY_AGING_SECONDS"`
+
+	// BatchContracts is a comma-separated list of contracts whose requests may
+	// be batched together through Multicall3. Empty turns batching off.
+	BatchContracts string `yaml:"BATCH_CONTRACTS"`
+
+	// BatchMaxSize is the largest number of requests in one batch.
+	BatchMaxSize int `yaml:"BATCH_MAX_SIZE"`
+
+	// MulticallAddress overrides the address of the Multicall3 contract.
+	MulticallAddress string `yaml:"MULTICALL_ADDRESS"`
+
+	// ForwarderAddress is the ERC-2771 forwarder that signed forward requests
+	// go through. Empty turns forward requests off.
+	ForwarderAddress string `yaml:"FORWARDER_ADDRESS"`
+
+	// ForwarderName is the name in the forwarder's EIP-712 domain.
+	ForwarderName string `yaml:"FORWARDER_NAME"`
+
+	// EntryPointAddress is the ERC-4337 EntryPoint, version 0.7, that user
+	// operations are bundled for. Empty turns user operations off.
+	EntryPointAddress string `yaml:"ENTRY_POINT_ADDRESS"`
+
+	// BundleMaxSize is the largest number of user operations in one handleOps
+	// call.
+	BundleMaxSize int `yaml:"BUNDLE_MAX_SIZE"`
+
+	// Chains configures chains served alongside the default one, which is
+	// described by the top-level settings. Lists can't come from environment
+	// variables, so this has to be set in the settings file.
+	Chains []ChainSettings `yaml:"CHAINS"`
+}
+
+// ChainSettings are the settings that vary between chains. The fields have the
+// same meaning as the top-level settings of the same name. A chain that leaves
+// CONFIRMATION_BLOCKS, BOOST_AFTER_BLOCKS, BLOCK_TIME, BATCH_MAX_SIZE or
+// BUNDLE_MAX_SIZE unset gets the top-level value.
+type ChainSettings struct {
+	EthereumRPCURL       string `yaml:"ETHEREUM_RPC_URL"`
+	KMSKeyIDs            string `yaml:"KMS_KEY_IDS"`
+	SenderPrivateKeys    string `yaml:"SENDER_PRIVATE_KEYS"`
+	ConfirmationBlocks   int64  `yaml:"CONFIRMATION_BLOCKS"`
+	FinalityTag          string `yaml:"FINALITY_TAG"`
+	BoostAfterBlocks     int64  `yaml:"BOOST_AFTER_BLOCKS"`
+	EscalationSchedule   string `yaml:"ESCALATION_SCHEDULE"`
+	BlockTime            int    `yaml:"BLOCK_TIME"`
+	DisableBoosting      bool   `yaml:"DISABLE_BOOSTING"`
+	SimulateTransactions bool   `yaml:"SIMULATE_TRANSACTIONS"`
+	AccessLists          bool   `yaml:"ACCESS_LISTS"`
+	ForwarderAddress     string `yaml:"FORWARDER_ADDRESS"`
+	ForwarderName        string `yaml:"FORWARDER_NAME"`
+	EntryPointAddress    string `yaml:"ENTRY_POINT_ADDRESS"`
+
+	GasPriceStrategy           string `yaml:"GAS_PRICE_STRATEGY"`
+	GasPricePriorityStrategies string `yaml:"GAS_PRICE_PRIORITY_STRATEGIES"`
+	GasStationURL              string `yaml:"GAS_STATION_URL"`
+	GasPriceFloor              string `yaml:"GAS_PRICE_FLOOR"`
+	GasPriceCap                string `yaml:"GAS_PRICE_CAP"`
+	MaxGasPrice                string `yaml:"MAX_GAS_PRICE"`
+	GasLimitPolicies           string `yaml:"GAS_LIMIT_POLICIES"`
+
+	PolicyAllowedContracts string `yaml:"POLICY_ALLOWED_CONTRACTS"`
+	PolicyAllowedSelectors string `yaml:"POLICY_ALLOWED_SELECTORS"`
+	PolicyMaxCalldataBytes int    `yaml:"POLICY_MAX_CALLDATA_BYTES"`
+	PolicyMaxValue         string `yaml:"POLICY_MAX_VALUE"`
+	PolicyMaxGasLimit      int    `yaml:"POLICY_MAX_GAS_LIMIT"`
+	PolicyAllowDeployments bool   `yaml:"POLICY_ALLOW_DEPLOYMENTS"`
+
+	BatchContracts   string `yaml:"BATCH_CONTRACTS"`
+	BatchMaxSize     int    `yaml:"BATCH_MAX_SIZE"`
+	MulticallAddress string `yaml:"MULTICALL_ADDRESS"`
+	BundleMaxSize    int    `yaml:"BUNDLE_MAX_SIZE"`
+}
+
+// AllChains returns the settings for every chain, starting with the default.
+// Missing block counts, block times and batch and bundle sizes are filled in
+// from the default.
+func (s *Settings) AllChains() []ChainSettings {
+	def := ChainSettings{
+		EthereumRPCURL:       s.EthereumRPCURL,
+		KMSKeyIDs:            s.KMSKeyIDs,
+		SenderPrivateKeys:    s.SenderPrivateKeys,
+		ConfirmationBlocks:   s.ConfirmationBlocks,
+		FinalityTag:          s.FinalityTag,
+		BoostAfterBlocks:     s.BoostAfterBlocks,
+		EscalationSchedule:   s.EscalationSchedule,
+		BlockTime:            s.BlockTime,
+		DisableBoosting:      s.DisableBoosting,
+		SimulateTransactions: s.SimulateTransactions,
+		AccessLists:          s.AccessLists,
+		ForwarderAddress:     s.ForwarderAddress,
+		ForwarderName:        s.ForwarderName,
+		EntryPointAddress:    s.EntryPointAddress,
+
+		GasPriceStrategy:           s.GasPriceStrategy,
+		GasPricePriorityStrategies: s.GasPricePriorityStrategies,
+		GasStationURL:              s.GasStationURL,
+		GasPriceFloor:              s.GasPriceFloor,
+		GasPriceCap:                s.GasPriceCap,
+		MaxGasPrice:                s.MaxGasPrice,
+		GasLimitPolicies:           s.GasLimitPolicies,
+
+		PolicyAllowedContracts: s.PolicyAllowedContracts,
+		PolicyAllowedSelectors: s.PolicyAllowedSelectors,
+		PolicyMaxCalldataBytes: s.PolicyMaxCalldataBytes,
+		PolicyMaxValue:         s.PolicyMaxValue,
+		PolicyMaxGasLimit:      s.PolicyMaxGasLimit,
+		PolicyAllowDeployments: s.PolicyAllowDeployments,
+
+		BatchContracts:   s.BatchContracts,
+		BatchMaxSize:     s.BatchMaxSize,
+		MulticallAddress: s.MulticallAddress,
+		BundleMaxSize:    s.BundleMaxSize,
+	}
+
+	out := []ChainSettings{def}
+
+	for _, ch := range s.Chains {
+		if ch.ConfirmationBlocks <= 0 {
+			ch.ConfirmationBlocks = def.ConfirmationBlocks
+		}
+		if ch.BoostAfterBlocks <= 0 {
+			ch.BoostAfterBlocks = def.BoostAfterBlocks
+		}
+		if ch.BlockTime <= 0 {
+			ch.BlockTime = def.BlockTime
+		}
+		if ch.BatchMaxSize <= 0 {
+			ch.BatchMaxSize = def.BatchMaxSize
+		}
+		if ch.BundleMaxSize <= 0 {
+			ch.BundleMaxSize = def.BundleMaxSize
+		}
+		out = append(out, ch)
+	}
+
+	return out
 }
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllChainsInheritsDefaults(t *testing.T) {
+	s := Settings{
+		EthereumRPCURL:     "http://polygon",
+		ConfirmationBlocks: 12,
+		BoostAfterBlocks:   30,
+		BlockTime:          2,
+		BatchMaxSize:       20,
+		BundleMaxSize:      10,
+		BatchContracts:     "0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1",
+		PolicyMaxValue:     "0",
+		Chains: []ChainSettings{
+			{EthereumRPCURL: "http://amoy", BoostAfterBlocks: 20, BundleMaxSize: 5},
+		},
+	}
+
+	chains := s.AllChains()
+
+	assert.Len(t, chains, 2)
+	assert.Equal(t, "http://polygon", chains[0].EthereumRPCURL)
+	assert.EqualValues(t, 12, chains[1].ConfirmationBlocks)
+	assert.EqualValues(t, 20, chains[1].BoostAfterBlocks)
+	assert.Equal(t, 2, chains[1].BlockTime)
+	assert.Equal(t, 20, chains[1].BatchMaxSize)
+	assert.Equal(t, 5, chains[1].BundleMaxSize)
+
+	// Addresses and policy limits don't carry over to other chains.
+	assert.Equal(t, "0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1", chains[0].BatchContracts)
+	assert.Equal(t, "0", chains[0].PolicyMaxValue)
+	assert.Empty(t, chains[1].BatchContracts)
+	assert.Empty(t, chains[1].PolicyMaxValue)
+}
@@ -1,20 +1,38 @@
 package consumer
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
 	"math/rand/v2"
+	"time"
 
+	"github.com/DIMO-Network/meta-transaction-processor/internal/entrypoint"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/forwarder"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/policy"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/quota"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/trace"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/IBM/sarama"
+	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
 var requestsTotal = promauto.NewCounter(
@@ -25,18 +43,83 @@ var requestsTotal = promauto.NewCounter(
 	},
 )
 
+var rejectedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Subsystem: "consumer",
+		Name:      "rejected_total",
+	},
+	[]string{"reason"},
+)
+
+// Chain is a chain that we take requests for.
+type Chain struct {
+	ID *big.Int
+	// NumWallets is the number of wallets sending transactions on the chain.
+	NumWallets int
+	// Forwarder relays forward requests. If it's nil, they're rejected.
+	Forwarder *forwarder.Forwarder
+	// EntryPoint takes user operations. If it's nil, they're rejected.
+	EntryPoint *entrypoint.EntryPoint
+	// Policy is what requests for the chain have to satisfy.
+	Policy *policy.Policy
+}
+
 type consumer struct {
-	logger     *zerolog.Logger
-	dbs        db.Store
-	numWallets int
+	logger   *zerolog.Logger
+	dbs      db.Store
+	chains   []*Chain
+	prod     status.Producer
+	dlq      sarama.SyncProducer
+	dlqTopic string
+	quotas   *quota.Quotas
 }
 
 type TransactionEventData struct {
-	ID   string         `json:"id"`
-	To   common.Address `json:"to"`
-	Data hexutil.Bytes  `json:"data"`
+	ID string `json:"id"`
+	// ChainID picks the chain to send the transaction on. Zero means the
+	// default chain.
+	ChainID uint64 `json:"chainId,omitempty"`
+	// To is the contract to call. If it's missing, the request deploys the
+	// contract whose creation code is in Data.
+	To   *common.Address `json:"to,omitempty"`
+	Data hexutil.Bytes   `json:"data"`
+	// ClientID identifies the sender for quota purposes. If it's empty, we use
+	// the CloudEvent source.
+	ClientID string `json:"clientId,omitempty"`
+	// Priority orders requests waiting for the same wallet. Higher goes first;
+	// the default is 0, and backfills should use negative numbers.
+	Priority int `json:"priority,omitempty"`
+	// NotBefore holds the request until the given time.
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	// ExpiresAt is the deadline for submitting the request. If it passes first,
+	// the request is dropped with an Expired status.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Value is the amount of wei to send with the call.
+	Value *hexutil.Big `json:"value,omitempty"`
+	// GasLimit overrides our estimate, for contracts where it's known to be
+	// wrong.
+	GasLimit *hexutil.Uint64 `json:"gasLimit,omitempty"`
+	// MaxGasPrice is the most wei per gas the request may pay. Above it, the
+	// request waits.
+	MaxGasPrice *hexutil.Big `json:"maxGasPrice,omitempty"`
+	// Forward is a request signed by a user, to be relayed through the trusted
+	// forwarder. It replaces To, Data and Value.
+	Forward *ForwardRequest `json:"forwardRequest,omitempty"`
+	// UserOp is an ERC-4337 user operation, to be bundled into a handleOps
+	// call. It replaces To, Data and Value.
+	UserOp *UserOperation `json:"userOperation,omitempty"`
+	// Expect is what the transaction must do. It's simulated before it's sent,
+	// and fails instead if it falls short.
+	Expect *trace.Expectation `json:"expect,omitempty"`
+
+	// userOpHash is filled in once the user operation has been checked.
+	userOpHash *common.Hash
 }
 
+// maxPriority bounds the absolute value of the priority field.
+const maxPriority = 1000
+
 func (c *consumer) Setup(sarama.ConsumerGroupSession) error { return nil }
 
 func (c *consumer) Cleanup(sarama.ConsumerGroupSession) error { return nil }
@@ -50,7 +133,11 @@ func (c *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 
 			var event shared.CloudEvent[TransactionEventData]
 			if err := json.Unmarshal(msg.Value, &event); err != nil {
-				logger.Err(err).Msg("Couldn't parse request, skipping.")
+				logger.Err(err).Msg("Couldn't parse request.")
+				if err := c.reportInvalid(session.Context(), msg, extractID(msg.Value), fmt.Errorf("couldn't parse request: %w", err)); err != nil {
+					logger.Err(err).Msg("Error reporting invalid request.")
+					return err
+				}
 				session.MarkMessage(msg, "")
 				continue
 			}
@@ -59,25 +146,151 @@ func (c *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 
 			if len(data.ID) != 27 {
 				logger.Error().Msgf("Invalid request id: %s.", data.ID)
+				if err := c.reportInvalid(session.Context(), msg, data.ID, fmt.Errorf("request id %q is not a 27-character KSUID", data.ID)); err != nil {
+					logger.Err(err).Msg("Error reporting invalid request.")
+					return err
+				}
 				session.MarkMessage(msg, "")
 				continue
 			}
 
-			logger = logger.With().Str("requestId", data.ID).Str("contract", data.To.Hex()).Logger()
+			contract := "deployment"
+			if data.To != nil {
+				contract = data.To.Hex()
+			} else if data.Forward != nil {
+				contract = data.Forward.To.Hex()
+			} else if data.UserOp != nil {
+				contract = data.UserOp.Sender.Hex()
+			}
 
-			assignedWalletIndex := rand.IntN(c.numWallets)
+			logger = logger.With().Str("requestId", data.ID).Str("contract", contract).Logger()
 
-			logger.Info().Int("assignedWalletIndex", assignedWalletIndex).Msg("Got transaction request.")
+			if err := validate(&data); err != nil {
+				logger.Err(err).Msg("Invalid request.")
+				if err := c.reportInvalid(session.Context(), msg, data.ID, err); err != nil {
+					logger.Err(err).Msg("Error reporting invalid request.")
+					return err
+				}
+				session.MarkMessage(msg, "")
+				continue
+			}
 
-			tx := models.MetaTransactionRequest{
-				ID:          data.ID,
-				To:          data.To.Bytes(),
-				Data:        data.Data,
-				WalletIndex: assignedWalletIndex,
+			ch := c.chain(data.ChainID)
+			if ch == nil {
+				logger.Error().Msgf("Unknown chain id %d.", data.ChainID)
+				if err := c.reportInvalid(session.Context(), msg, data.ID, fmt.Errorf("chain id %d is not served here", data.ChainID)); err != nil {
+					logger.Err(err).Msg("Error reporting invalid request.")
+					return err
+				}
+				session.MarkMessage(msg, "")
+				continue
 			}
 
-			// Don't really want to update.
-			if err := tx.Upsert(session.Context(), c.dbs.DBS().Writer, false, []string{models.MetaTransactionRequestColumns.ID}, boil.None(), boil.Infer()); err != nil {
+			logger = logger.With().Str("chainId", ch.ID.String()).Logger()
+
+			// Without an EntryPoint there's nothing to hold the operation to the
+			// policy against.
+			if data.UserOp != nil && ch.EntryPoint == nil {
+				logger.Warn().Msg("User operations are not enabled, rejecting.")
+				if err := c.reject(session.Context(), c.dbs.DBS().Writer, data.ID, status.ReasonPolicy, "User operations are not enabled."); err != nil {
+					logger.Err(err).Msg("Error rejecting request.")
+					return err
+				}
+				session.MarkMessage(msg, "")
+				continue
+			}
+
+			polReq := &policy.Request{To: data.To, Data: data.Data, Value: data.Value.ToInt()}
+			if data.Forward != nil {
+				// Hold the user's call to the policy, not our call to the forwarder.
+				polReq = &policy.Request{To: &data.Forward.To, Data: data.Forward.Data, Value: data.Forward.Value.ToInt()}
+			} else if data.UserOp != nil {
+				// We can't see what the account will do, only that we're calling
+				// the EntryPoint.
+				ep := ch.EntryPoint.Address()
+				polReq = &policy.Request{To: &ep}
+			}
+			if data.GasLimit != nil {
+				polReq.GasLimit = uint64(*data.GasLimit)
+			}
+
+			if err := ch.Policy.Check(polReq); err != nil {
+				logger.Warn().Err(err).Msg("Request not allowed by policy, rejecting.")
+				if err := c.reject(session.Context(), c.dbs.DBS().Writer, data.ID, status.ReasonPolicy, err.Error()); err != nil {
+					logger.Err(err).Msg("Error rejecting request.")
+					return err
+				}
+				session.MarkMessage(msg, "")
+				continue
+			}
+
+			// Hash before the forward request or user operation rewrites the
+			// call, and look for repeats before checking either, since a replay
+			// of one that has already been executed would fail on its nonce.
+			hash, err := payloadHash(&data)
+			if err != nil {
+				logger.Err(err).Msg("Error hashing request.")
+				return err
+			}
+
+			if seen, err := c.answerRepeat(session.Context(), &logger, c.dbs.DBS().Writer, data.ID, hash, false); err != nil {
+				logger.Err(err).Msg("Error checking request history.")
+				return err
+			} else if seen {
+				session.MarkMessage(msg, "")
+				continue
+			}
+
+			if data.Forward != nil {
+				reason, message := "", ""
+				if ch.Forwarder == nil {
+					reason, message = status.ReasonPolicy, "Forward requests are not enabled."
+				} else if err := forward(session.Context(), ch.Forwarder, &data); err != nil {
+					var inv *forwarder.Invalid
+					if !errors.As(err, &inv) {
+						logger.Err(err).Msg("Error checking forward request.")
+						return err
+					}
+					reason, message = status.ReasonForwardRequest, err.Error()
+				}
+
+				if reason != "" {
+					logger.Warn().Str("reason", reason).Msgf("Refusing forward request: %s", message)
+					if err := c.reject(session.Context(), c.dbs.DBS().Writer, data.ID, reason, message); err != nil {
+						logger.Err(err).Msg("Error rejecting request.")
+						return err
+					}
+					session.MarkMessage(msg, "")
+					continue
+				}
+			}
+
+			if data.UserOp != nil {
+				if err := userOp(session.Context(), ch.EntryPoint, &data); err != nil {
+					var inv *entrypoint.Invalid
+					if !errors.As(err, &inv) {
+						logger.Err(err).Msg("Error checking user operation.")
+						return err
+					}
+
+					logger.Warn().Msgf("Refusing user operation: %s", err)
+					if err := c.reject(session.Context(), c.dbs.DBS().Writer, data.ID, status.ReasonUserOperation, err.Error()); err != nil {
+						logger.Err(err).Msg("Error rejecting request.")
+						return err
+					}
+					session.MarkMessage(msg, "")
+					continue
+				}
+			}
+
+			clientID := data.ClientID
+			if clientID == "" {
+				clientID = event.Source
+			}
+
+			logger = logger.With().Str("clientId", clientID).Logger()
+
+			if err := c.intake(session.Context(), &logger, ch, &data, clientID, hash); err != nil {
 				logger.Err(err).Msg("Error saving transaction.")
 				return err
 			}
@@ -89,13 +302,238 @@ func (c *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 	}
 }
 
-func New(ctx context.Context, name string, topic string, kafkaClient sarama.Client, logger *zerolog.Logger, dbs db.Store, numWallets int) error {
+// chain returns the chain with the given id, or the default chain for zero. It
+// returns nil if we don't serve the chain.
+func (c *consumer) chain(id uint64) *Chain {
+	if id == 0 {
+		return c.chains[0]
+	}
+	for _, ch := range c.chains {
+		if ch.ID.IsUint64() && ch.ID.Uint64() == id {
+			return ch
+		}
+	}
+	return nil
+}
+
+// validate checks the fields of a request, other than the id, for values that
+// can never work.
+func validate(data *TransactionEventData) error {
+	if data.Forward != nil {
+		if data.To != nil || len(data.Data) != 0 || data.Value != nil || data.UserOp != nil {
+			return errors.New("forwardRequest can't be combined with to, data, value or userOperation")
+		}
+		if data.Forward.Nonce == nil {
+			return errors.New("forwardRequest has no nonce")
+		}
+	} else if data.UserOp != nil {
+		if data.To != nil || len(data.Data) != 0 || data.Value != nil || data.GasLimit != nil {
+			return errors.New("userOperation can't be combined with to, data, value or gasLimit")
+		}
+		if data.UserOp.Nonce == nil || data.UserOp.PreVerificationGas == nil {
+			return errors.New("userOperation is missing nonce or preVerificationGas")
+		}
+	} else if data.To == nil && len(data.Data) == 0 {
+		return errors.New("request has neither a destination nor contract code to deploy")
+	}
+
+	if data.Priority > maxPriority || data.Priority < -maxPriority {
+		return fmt.Errorf("priority %d is outside the range [-%d, %d]", data.Priority, maxPriority, maxPriority)
+	}
+
+	if data.NotBefore != nil && data.ExpiresAt != nil && !data.ExpiresAt.After(*data.NotBefore) {
+		return fmt.Errorf("expiresAt %s is not after notBefore %s", data.ExpiresAt.Format(time.RFC3339), data.NotBefore.Format(time.RFC3339))
+	}
+
+	if data.MaxGasPrice != nil && data.MaxGasPrice.ToInt().Sign() <= 0 {
+		return errors.New("maxGasPrice must be positive")
+	}
+
+	if data.GasLimit != nil && uint64(*data.GasLimit) < params.TxGas {
+		return fmt.Errorf("gas limit %d is below the minimum of %d", *data.GasLimit, params.TxGas)
+	}
+
+	if data.Expect != nil {
+		if data.UserOp != nil {
+			return errors.New("expect can't be combined with userOperation")
+		}
+		if err := data.Expect.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// payloadHash fingerprints the contents of a request, so that we can tell a
+// redelivery apart from a different request that reuses an id.
+func payloadHash(data *TransactionEventData) (common.Hash, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(b), nil
+}
+
+// intake stores a new request. Every id is remembered, even after its request
+// is done and deleted, so that a replay is never executed twice. Replays, reused
+// ids, and requests over the client's rate limit are answered with a Rejected
+// status instead.
+func (c *consumer) intake(ctx context.Context, logger *zerolog.Logger, ch *Chain, data *TransactionEventData, clientID string, hash common.Hash) error {
+	dbTx, err := c.dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback() //nolint:errcheck
+
+	seen, err := c.answerRepeat(ctx, logger, dbTx, data.ID, hash, true)
+	if err != nil {
+		return err
+	}
+
+	if seen {
+		return dbTx.Commit()
+	}
+
+	allowed, err := c.quotas.AllowRequest(ctx, dbTx, clientID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		// Not remembering the id, so the request can be retried later.
+		logger.Warn().Msg("Client over its request rate limit, rejecting.")
+		if err := c.reject(ctx, dbTx, data.ID, status.ReasonRateLimited, fmt.Sprintf("Client %q sent more than %d requests this minute.", clientID, c.quotas.For(clientID).RequestsPerMinute)); err != nil {
+			return err
+		}
+		return dbTx.Commit()
+	}
+
+	received := models.ReceivedRequest{
+		ID:          data.ID,
+		PayloadHash: hash.Bytes(),
+	}
+
+	if err := received.Insert(ctx, dbTx, boil.Infer()); err != nil {
+		return fmt.Errorf("failed to record request: %w", err)
+	}
+
+	assignedWalletIndex := rand.IntN(ch.NumWallets)
+
+	logger.Info().Int("assignedWalletIndex", assignedWalletIndex).Msg("Got transaction request.")
+
+	tx := models.MetaTransactionRequest{
+		ID:          data.ID,
+		Data:        data.Data,
+		WalletIndex: assignedWalletIndex,
+		ChainID:     null.Int64From(ch.ID.Int64()),
+		ClientID:    clientID,
+		Priority:    data.Priority,
+		NotBefore:   null.TimeFromPtr(data.NotBefore),
+		ExpiresAt:   null.TimeFromPtr(data.ExpiresAt),
+	}
+
+	if data.To != nil {
+		tx.To = null.BytesFrom(data.To.Bytes())
+	}
+
+	if data.userOpHash != nil {
+		tx.UserOpHash = null.BytesFrom(data.userOpHash.Bytes())
+	}
+
+	if data.Value != nil && data.Value.ToInt().Sign() != 0 {
+		tx.Value = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(data.Value.ToInt(), 0))
+	}
+
+	if data.GasLimit != nil {
+		tx.GasLimit = types.NewNullDecimal(new(decimal.Big).SetUint64(uint64(*data.GasLimit)))
+	}
+
+	if data.MaxGasPrice != nil {
+		tx.MaxGasPrice = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(data.MaxGasPrice.ToInt(), 0))
+	}
+
+	if data.Expect != nil {
+		if err := tx.Expectation.Marshal(data.Expect); err != nil {
+			return err
+		}
+	}
+
+	// Requests that were in flight before we kept a history won't have an entry
+	// above, so this may still collide. Don't really want to update.
+	if err := tx.Upsert(ctx, dbTx, false, []string{models.MetaTransactionRequestColumns.ID}, boil.None(), boil.Infer()); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
+
+// answerRepeat looks the id up in the request history. If it's there, the
+// request is a redelivery, a replay or a reused id, and answerRepeat deals with
+// it and returns true. lock holds the history row for the rest of exec's
+// transaction.
+func (c *consumer) answerRepeat(ctx context.Context, logger *zerolog.Logger, exec boil.ContextExecutor, id string, hash common.Hash, lock bool) (bool, error) {
+	mods := []qm.QueryMod{models.ReceivedRequestWhere.ID.EQ(id)}
+	if lock {
+		mods = append(mods, qm.For("UPDATE"))
+	}
+
+	seen, err := models.ReceivedRequests(mods...).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to look up request history: %w", err)
+	}
+
+	if !bytes.Equal(seen.PayloadHash, hash.Bytes()) {
+		logger.Warn().Msg("Request id reused with a different payload, rejecting.")
+		return true, c.reject(ctx, exec, id, status.ReasonConflict, "A request with this id but a different payload was already received.")
+	}
+
+	pending, err := models.MetaTransactionRequestExists(ctx, exec, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for pending request: %w", err)
+	}
+
+	if !pending {
+		logger.Warn().Msg("Request already processed, rejecting replay.")
+		return true, c.reject(ctx, exec, id, status.ReasonDuplicate, "This request was already processed.")
+	}
+
+	// Plain redelivery of something we're still working on.
+	logger.Info().Msg("Ignoring redelivered request.")
+	return true, nil
+}
+
+// reject emits a Rejected status for the request.
+func (c *consumer) reject(ctx context.Context, exec boil.ContextExecutor, id, reason, message string) error {
+	rejectedTotal.With(prometheus.Labels{"reason": reason}).Inc()
+
+	return c.prod.Rejected(ctx, exec, &status.RejectedMsg{ID: id, Reason: reason, Message: message})
+}
+
+// New consumes requests from the topic until the context is canceled. Messages
+// that can't be turned into requests are forwarded to deadLetterTopic, unless
+// it's empty. Requests that the policy doesn't allow, or that go over the
+// client's quotas, are rejected. Requests that don't name a chain go to the
+// first of chains.
+func New(ctx context.Context, name string, topic string, deadLetterTopic string, kafkaClient sarama.Client, logger *zerolog.Logger, dbs db.Store, chains []*Chain, prod status.Producer, quotas *quota.Quotas) error {
 	group, err := sarama.NewConsumerGroupFromClient(name, kafkaClient)
 	if err != nil {
 		return err
 	}
 
-	consumer := &consumer{logger: logger, dbs: dbs, numWallets: numWallets}
+	consumer := &consumer{logger: logger, dbs: dbs, chains: chains, prod: prod, quotas: quotas}
+
+	if deadLetterTopic != "" {
+		dlq, err := sarama.NewSyncProducerFromClient(kafkaClient)
+		if err != nil {
+			return err
+		}
+		consumer.dlq = dlq
+		consumer.dlqTopic = deadLetterTopic
+	}
 
 	for {
 		err := group.Consume(ctx, []string{topic}, consumer)
@@ -0,0 +1,323 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/mocks"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/policy"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/IBM/sarama"
+	"github.com/docker/go-connections/nat"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pressly/goose/v3"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.uber.org/mock/gomock"
+)
+
+type ConsumerTestSuite struct {
+	suite.Suite
+
+	pgCont *postgres.PostgresContainer
+	dbs    db.Store
+
+	c        *consumer
+	producer *mocks.MockProducer
+}
+
+func TestConsumerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsumerTestSuite))
+}
+
+func (s *ConsumerTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	container, err := postgres.Run(
+		ctx,
+		"docker.io/postgres:16.6-alpine",
+		postgres.WithDatabase("meta_transaction_processor"),
+		postgres.WithUsername("dimo"),
+		postgres.WithPassword("dimo"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second),
+		),
+	)
+	s.Require().NoError(err)
+
+	s.pgCont = container
+
+	h, err := container.Host(ctx)
+	s.Require().NoError(err)
+
+	p, err := container.MappedPort(ctx, nat.Port("5432/tcp"))
+	s.Require().NoError(err)
+
+	settings := db.Settings{
+		User:               "dimo",
+		Password:           "dimo",
+		Port:               p.Port(),
+		Host:               h,
+		Name:               "meta_transaction_processor",
+		MaxOpenConnections: 2,
+		MaxIdleConnections: 2,
+	}
+
+	dbs := db.NewDbConnectionFromSettings(ctx, &settings, false)
+	for !dbs.IsReady() {
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	s.dbs = dbs
+
+	_, err = dbs.DBS().Writer.Exec(`CREATE SCHEMA IF NOT EXISTS meta_transaction_processor;`)
+	s.Require().NoError(err)
+
+	goose.SetTableName("meta_transaction_processor.migrations")
+	err = goose.RunContext(ctx, "up", dbs.DBS().Writer.DB, "../../migrations")
+	s.Require().NoError(err)
+}
+
+func (s *ConsumerTestSuite) TearDownSuite() {
+	s.Require().NoError(s.pgCont.Terminate(context.Background()))
+}
+
+func (s *ConsumerTestSuite) SetupTest() {
+	ctx := context.Background()
+
+	_, err := models.MetaTransactionRequests().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	_, err = models.ReceivedRequests().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	pol, err := policy.New("", "", 0, "", 0, false)
+	s.Require().NoError(err)
+
+	logger := zerolog.Nop()
+
+	s.producer = mocks.NewMockProducer(gomock.NewController(s.T()))
+
+	s.c = &consumer{
+		logger: &logger,
+		dbs:    s.dbs,
+		chains: []*Chain{{ID: big.NewInt(137), NumWallets: 1, Policy: pol}},
+		prod:   s.producer,
+	}
+}
+
+// request builds a request to call the address with the given data.
+func request(id string, to common.Address, data string) *TransactionEventData {
+	return &TransactionEventData{ID: id, To: &to, Data: common.FromHex(data)}
+}
+
+// consume runs the request through the consumer as a Kafka message.
+func (s *ConsumerTestSuite) consume(data *TransactionEventData) {
+	value, err := json.Marshal(shared.CloudEvent[*TransactionEventData]{
+		ID:     ksuid.New().String(),
+		Source: "test",
+		Type:   "zone.dimo.transaction.request",
+		Data:   data,
+	})
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs := make(chan *sarama.ConsumerMessage, 1)
+	msgs <- &sarama.ConsumerMessage{Topic: "requests", Value: value}
+
+	sess := &testSession{ctx: ctx, cancel: cancel}
+
+	err = s.c.ConsumeClaim(sess, &testClaim{msgs: msgs})
+	s.Require().NoError(err)
+	s.Equal(1, sess.marked)
+}
+
+func (s *ConsumerTestSuite) count() int64 {
+	n, err := models.MetaTransactionRequests().Count(context.Background(), s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	return n
+}
+
+func (s *ConsumerTestSuite) TestRedelivery() {
+	req := request(ksuid.New().String(), common.HexToAddress("0x01"), "0x7050f4c0")
+
+	s.consume(req)
+	s.EqualValues(1, s.count())
+
+	// Still pending, so the copy is ignored.
+	s.consume(req)
+	s.EqualValues(1, s.count())
+}
+
+func (s *ConsumerTestSuite) TestReplay() {
+	ctx := context.Background()
+
+	req := request(ksuid.New().String(), common.HexToAddress("0x01"), "0x7050f4c0")
+
+	s.consume(req)
+
+	// The request is done.
+	_, err := models.MetaTransactionRequests().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
+	s.producer.EXPECT().Rejected(gomock.Any(), gomock.Any(), &status.RejectedMsg{
+		ID:      req.ID,
+		Reason:  status.ReasonDuplicate,
+		Message: "This request was already processed.",
+	})
+
+	s.consume(req)
+	s.Zero(s.count())
+}
+
+func (s *ConsumerTestSuite) TestConflict() {
+	id := ksuid.New().String()
+
+	s.consume(request(id, common.HexToAddress("0x01"), "0x7050f4c0"))
+
+	s.producer.EXPECT().Rejected(gomock.Any(), gomock.Any(), &status.RejectedMsg{
+		ID:      id,
+		Reason:  status.ReasonConflict,
+		Message: "A request with this id but a different payload was already received.",
+	})
+
+	s.consume(request(id, common.HexToAddress("0x01"), "0xf583dabb"))
+
+	mtr, err := models.FindMetaTransactionRequest(context.Background(), s.dbs.DBS().Reader, id)
+	s.Require().NoError(err)
+	s.Equal(common.FromHex("0x7050f4c0"), mtr.Data)
+}
+
+// Two copies can both get past the unlocked check before either is stored.
+// The check under the lock has to catch the second.
+func (s *ConsumerTestSuite) TestRepeatAfterFastPath() {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	id := ksuid.New().String()
+	first := request(id, common.HexToAddress("0x01"), "0x7050f4c0")
+	second := request(id, common.HexToAddress("0x01"), "0xf583dabb")
+
+	firstHash, err := payloadHash(first)
+	s.Require().NoError(err)
+	secondHash, err := payloadHash(second)
+	s.Require().NoError(err)
+
+	for _, h := range []common.Hash{firstHash, secondHash} {
+		seen, err := s.c.answerRepeat(ctx, &logger, s.dbs.DBS().Writer, id, h, false)
+		s.Require().NoError(err)
+		s.False(seen)
+	}
+
+	err = s.c.intake(ctx, &logger, s.c.chains[0], first, "test", firstHash)
+	s.Require().NoError(err)
+
+	// The same payload again is a redelivery.
+	err = s.c.intake(ctx, &logger, s.c.chains[0], first, "test", firstHash)
+	s.Require().NoError(err)
+
+	s.producer.EXPECT().Rejected(gomock.Any(), gomock.Any(), &status.RejectedMsg{
+		ID:      id,
+		Reason:  status.ReasonConflict,
+		Message: "A request with this id but a different payload was already received.",
+	})
+
+	err = s.c.intake(ctx, &logger, s.c.chains[0], second, "test", secondHash)
+	s.Require().NoError(err)
+
+	s.EqualValues(1, s.count())
+
+	mtr, err := models.FindMetaTransactionRequest(ctx, s.dbs.DBS().Reader, id)
+	s.Require().NoError(err)
+	s.Equal(common.FromHex("0x7050f4c0"), mtr.Data)
+}
+
+func (s *ConsumerTestSuite) TestInvalid() {
+	id := ksuid.New().String()
+	req := request(id, common.HexToAddress("0x01"), "0x7050f4c0")
+	req.Priority = 5000
+
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), &status.FailedMsg{
+		ID:      id,
+		Reason:  status.ReasonInvalid,
+		Message: "priority 5000 is outside the range [-1000, 1000]",
+	})
+
+	s.consume(req)
+
+	// Whatever happens to a copy, the request we have stands.
+	req.Priority = 0
+	s.consume(req)
+
+	req.Priority = 5000
+	s.consume(req)
+
+	s.EqualValues(1, s.count())
+}
+
+func (s *ConsumerTestSuite) TestUserOpWithoutEntryPoint() {
+	// An allowlist that would turn away the operation, if it got that far.
+	pol, err := policy.New("0x0000000000000000000000000000000000000001", "", 0, "", 0, false)
+	s.Require().NoError(err)
+	s.c.chains[0].Policy = pol
+
+	id := ksuid.New().String()
+
+	s.producer.EXPECT().Rejected(gomock.Any(), gomock.Any(), &status.RejectedMsg{
+		ID:      id,
+		Reason:  status.ReasonPolicy,
+		Message: "User operations are not enabled.",
+	})
+
+	s.consume(&TransactionEventData{
+		ID: id,
+		UserOp: &UserOperation{
+			Sender:             common.HexToAddress("0x02"),
+			Nonce:              (*hexutil.Big)(big.NewInt(0)),
+			PreVerificationGas: (*hexutil.Big)(big.NewInt(50_000)),
+		},
+	})
+
+	s.Zero(s.count())
+}
+
+// testSession is a consumer group session that ends once a message is marked.
+type testSession struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	marked int
+}
+
+func (t *testSession) Claims() map[string][]int32                  { return nil }
+func (t *testSession) MemberID() string                            { return "" }
+func (t *testSession) GenerationID() int32                         { return 0 }
+func (t *testSession) MarkOffset(string, int32, int64, string)     {}
+func (t *testSession) Commit()                                     {}
+func (t *testSession) ResetOffset(string, int32, int64, string)    {}
+func (t *testSession) Context() context.Context                    { return t.ctx }
+func (t *testSession) MarkMessage(*sarama.ConsumerMessage, string) { t.marked++; t.cancel() }
+
+type testClaim struct {
+	msgs chan *sarama.ConsumerMessage
+}
+
+func (t *testClaim) Topic() string                            { return "requests" }
+func (t *testClaim) Partition() int32                         { return 0 }
+func (t *testClaim) InitialOffset() int64                     { return 0 }
+func (t *testClaim) HighWaterMarkOffset() int64               { return 0 }
+func (t *testClaim) Messages() <-chan *sarama.ConsumerMessage { return t.msgs }
@@ -0,0 +1,107 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/ksuid"
+)
+
+var deadLetteredTotal = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Subsystem: "consumer",
+		Name:      "dead_lettered_total",
+	},
+)
+
+// deadLetter is what we put on the dead-letter topic: the original message,
+// untouched, along with where it came from and why we gave up on it.
+type deadLetter struct {
+	Error     string    `json:"error"`
+	Topic     string    `json:"topic"`
+	Partition int32     `json:"partition"`
+	Offset    int64     `json:"offset"`
+	Timestamp time.Time `json:"timestamp"`
+	Key       []byte    `json:"key,omitempty"`
+	Value     []byte    `json:"value"`
+}
+
+// extractID makes a best effort at finding the request id in a message that
+// failed to parse. It only fails if the JSON is malformed or the id isn't a
+// string.
+func extractID(value []byte) string {
+	var probe struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+
+	// Type errors elsewhere in the message don't stop the id from being filled
+	// in, so the error isn't interesting.
+	_ = json.Unmarshal(value, &probe)
+
+	return probe.Data.ID
+}
+
+// reportInvalid forwards the message to the dead-letter topic, if there is one,
+// and sends a Failed status if we know which request the message was for. The
+// id has to be a KSUID that we haven't seen before: a Failed status for a
+// request we've already taken, or for an id that can't be one of ours, would
+// only confuse whoever is watching it.
+func (c *consumer) reportInvalid(ctx context.Context, msg *sarama.ConsumerMessage, id string, reason error) error {
+	deadLetteredTotal.Inc()
+
+	if c.dlq != nil {
+		bs, err := json.Marshal(deadLetter{
+			Error:     reason.Error(),
+			Topic:     msg.Topic,
+			Partition: msg.Partition,
+			Offset:    msg.Offset,
+			Timestamp: msg.Timestamp,
+			Key:       msg.Key,
+			Value:     msg.Value,
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't marshal dead letter: %w", err)
+		}
+
+		_, _, err = c.dlq.SendMessage(&sarama.ProducerMessage{
+			Topic: c.dlqTopic,
+			Key:   sarama.ByteEncoder(msg.Key),
+			Value: sarama.ByteEncoder(bs),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send to dead-letter topic: %w", err)
+		}
+	}
+
+	if _, err := ksuid.Parse(id); err != nil {
+		return nil
+	}
+
+	if seen, err := models.ReceivedRequestExists(ctx, c.dbs.DBS().Reader, id); err != nil {
+		return fmt.Errorf("failed to look up request history: %w", err)
+	} else if seen {
+		return nil
+	}
+
+	if pending, err := models.MetaTransactionRequestExists(ctx, c.dbs.DBS().Reader, id); err != nil {
+		return fmt.Errorf("failed to check for pending request: %w", err)
+	} else if pending {
+		return nil
+	}
+
+	return c.prod.Failed(ctx, c.dbs.DBS().Writer, &status.FailedMsg{
+		ID:      id,
+		Reason:  status.ReasonInvalid,
+		Message: reason.Error(),
+	})
+}
@@ -0,0 +1,27 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		id    string
+	}{
+		{"Valid", `{"data": {"id": "2FowjlIXxjSsbGbtwcDbA1gRdXt"}}`, "2FowjlIXxjSsbGbtwcDbA1gRdXt"},
+		{"BadFieldType", `{"data": {"id": "2FowjlIXxjSsbGbtwcDbA1gRdXt", "to": 5}, "time": false}`, "2FowjlIXxjSsbGbtwcDbA1gRdXt"},
+		{"NumericID", `{"data": {"id": 5}}`, ""},
+		{"Malformed", `{"data": {"id": "2FowjlIXxjSsbGbtwcDbA1gRdXt"`, ""},
+		{"NoData", `{"id": "2FowjlIXxjSsbGbtwcDbA1gRdXt"}`, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.id, extractID([]byte(tc.value)))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package consumer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/forwarder"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ForwardRequest is an ERC-2771 request signed by a user, to be executed
+// through the trusted forwarder. The signature covers every field here, along
+// with the forwarder's EIP-712 domain.
+type ForwardRequest struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *hexutil.Big   `json:"value,omitempty"`
+	// Gas is the gas limit for the inner call.
+	Gas      hexutil.Uint64 `json:"gas"`
+	Nonce    *hexutil.Big   `json:"nonce"`
+	Deadline hexutil.Uint64 `json:"deadline"`
+	Data     hexutil.Bytes  `json:"data"`
+	// Signature is 65 bytes, with a recovery id of 27 or 28 at the end.
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+func (r *ForwardRequest) toForwarder() *forwarder.Request {
+	return &forwarder.Request{
+		From:      r.From,
+		To:        r.To,
+		Value:     r.Value.ToInt(),
+		Gas:       new(big.Int).SetUint64(uint64(r.Gas)),
+		Nonce:     r.Nonce.ToInt(),
+		Deadline:  uint64(r.Deadline),
+		Data:      r.Data,
+		Signature: r.Signature,
+	}
+}
+
+// forward checks the signed request in data.Forward and, if the forwarder would
+// accept it, turns data into a call to the forwarder's execute. The request
+// expires at the deadline, if not before. Requests the forwarder would refuse
+// get a *forwarder.Invalid.
+func forward(ctx context.Context, fwd *forwarder.Forwarder, data *TransactionEventData) error {
+	req := data.Forward.toForwarder()
+
+	if err := fwd.Verify(ctx, req, time.Now()); err != nil {
+		return err
+	}
+
+	calldata, err := forwarder.ExecuteData(req)
+	if err != nil {
+		return err
+	}
+
+	to := fwd.Address()
+
+	data.To = &to
+	data.Data = calldata
+	// The forwarder requires that we send exactly the value that was signed.
+	data.Value = data.Forward.Value
+
+	deadline := time.Unix(int64(req.Deadline), 0)
+	if data.ExpiresAt == nil || deadline.Before(*data.ExpiresAt) {
+		data.ExpiresAt = &deadline
+	}
+
+	return nil
+}
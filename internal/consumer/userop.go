@@ -0,0 +1,81 @@
+package consumer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/entrypoint"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UserOperation is an ERC-4337 PackedUserOperation, for version 0.7 of the
+// EntryPoint.
+type UserOperation struct {
+	Sender             common.Address `json:"sender"`
+	Nonce              *hexutil.Big   `json:"nonce"`
+	InitCode           hexutil.Bytes  `json:"initCode"`
+	CallData           hexutil.Bytes  `json:"callData"`
+	AccountGasLimits   common.Hash    `json:"accountGasLimits"`
+	PreVerificationGas *hexutil.Big   `json:"preVerificationGas"`
+	GasFees            common.Hash    `json:"gasFees"`
+	PaymasterAndData   hexutil.Bytes  `json:"paymasterAndData"`
+	Signature          hexutil.Bytes  `json:"signature"`
+}
+
+func (o *UserOperation) toEntryPoint() *entrypoint.UserOperation {
+	return &entrypoint.UserOperation{
+		Sender:             o.Sender,
+		Nonce:              o.Nonce.ToInt(),
+		InitCode:           o.InitCode,
+		CallData:           o.CallData,
+		AccountGasLimits:   o.AccountGasLimits,
+		PreVerificationGas: o.PreVerificationGas.ToInt(),
+		GasFees:            o.GasFees,
+		PaymasterAndData:   o.PaymasterAndData,
+		Signature:          o.Signature,
+	}
+}
+
+// maxFeePerGas is the operation's maxFeePerGas, which is packed into the low
+// 16 bytes of GasFees.
+func (o *UserOperation) maxFeePerGas() *big.Int {
+	return new(big.Int).SetBytes(o.GasFees[16:])
+}
+
+// userOp simulates the operation in data.UserOp and, if the EntryPoint accepts
+// it, points data at the EntryPoint with the encoded operation as the data.
+// The ticker bundles these into handleOps calls. Operations that the
+// EntryPoint refuses get an *entrypoint.Invalid.
+//
+// The EntryPoint pays us back at no more than the operation's maxFeePerGas, so
+// that becomes the request's gas price ceiling if it's lower.
+func userOp(ctx context.Context, ep *entrypoint.EntryPoint, data *TransactionEventData) error {
+	op := data.UserOp.toEntryPoint()
+
+	if err := ep.Simulate(ctx, op); err != nil {
+		return err
+	}
+
+	hash, err := ep.Hash(ctx, op)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := op.Encode()
+	if err != nil {
+		return err
+	}
+
+	to := ep.Address()
+
+	data.To = &to
+	data.Data = encoded
+	data.userOpHash = &hash
+
+	if fee := data.UserOp.maxFeePerGas(); fee.Sign() > 0 && (data.MaxGasPrice == nil || fee.Cmp(data.MaxGasPrice.ToInt()) < 0) {
+		data.MaxGasPrice = (*hexutil.Big)(fee)
+	}
+
+	return nil
+}
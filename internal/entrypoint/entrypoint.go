@@ -0,0 +1,232 @@
+package entrypoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// This is for version 0.7 of the ERC-4337 EntryPoint, which takes packed user
+// operations.
+
+const abiJSON = `[{"type":"function","name":"handleOps","stateMutability":"nonpayable","inputs":[{"components":[{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},{"name":"accountGasLimits","type":"bytes32"},{"name":"preVerificationGas","type":"uint256"},{"name":"gasFees","type":"bytes32"},{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}],"internalType":"struct PackedUserOperation[]","type":"tuple[]","name":"ops"},{"name":"beneficiary","type":"address"}],"outputs":[]},{"type":"function","name":"getUserOpHash","stateMutability":"view","inputs":[{"components":[{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},{"name":"accountGasLimits","type":"bytes32"},{"name":"preVerificationGas","type":"uint256"},{"name":"gasFees","type":"bytes32"},{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}],"internalType":"struct PackedUserOperation","type":"tuple","name":"userOp"}],"outputs":[{"name":"","type":"bytes32"}]},{"type":"event","name":"UserOperationEvent","anonymous":false,"inputs":[{"name":"userOpHash","type":"bytes32","indexed":true},{"name":"sender","type":"address","indexed":true},{"name":"paymaster","type":"address","indexed":true},{"name":"nonce","type":"uint256","indexed":false},{"name":"success","type":"bool","indexed":false},{"name":"actualGasCost","type":"uint256","indexed":false},{"name":"actualGasUsed","type":"uint256","indexed":false}]},{"type":"event","name":"UserOperationRevertReason","anonymous":false,"inputs":[{"name":"userOpHash","type":"bytes32","indexed":true},{"name":"sender","type":"address","indexed":true},{"name":"nonce","type":"uint256","indexed":false},{"name":"revertReason","type":"bytes","indexed":false}]},{"type":"event","name":"BeforeExecution","anonymous":false,"inputs":[]},{"type":"error","name":"FailedOp","inputs":[{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"}]},{"type":"error","name":"FailedOpWithRevert","inputs":[{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"},{"name":"inner","type":"bytes"}]}]`
+
+var contractABI = func() abi.ABI {
+	a, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+	return a
+}()
+
+// opArgs encodes a single user operation on its own, which is how we store it.
+var opArgs = abi.Arguments{contractABI.Methods["getUserOpHash"].Inputs[0]}
+
+// UserOperation is a PackedUserOperation.
+type UserOperation struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// Encode ABI-encodes the operation.
+func (op *UserOperation) Encode() ([]byte, error) {
+	return opArgs.Pack(op)
+}
+
+// Decode reverses Encode.
+func Decode(data []byte) (*UserOperation, error) {
+	vals, err := opArgs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode user operation: %w", err)
+	}
+
+	return abi.ConvertType(vals[0], new(UserOperation)).(*UserOperation), nil
+}
+
+// HandleOpsData encodes a call to handleOps. The beneficiary is paid for the
+// gas.
+func HandleOpsData(ops []*UserOperation, beneficiary common.Address) ([]byte, error) {
+	vals := make([]UserOperation, len(ops))
+	for i, op := range ops {
+		vals[i] = *op
+	}
+	return contractABI.Pack("handleOps", vals, beneficiary)
+}
+
+// Invalid is the error returned for an operation that the EntryPoint refuses.
+type Invalid struct {
+	msg string
+}
+
+func (e *Invalid) Error() string {
+	return e.msg
+}
+
+// EntryPoint checks user operations against a deployed EntryPoint.
+type EntryPoint struct {
+	address common.Address
+	caller  ethereum.ContractCaller
+	// from is the account we simulate from. It also gets the fees.
+	from common.Address
+}
+
+// New creates an EntryPoint for the contract at address. Simulations run as if
+// from sent them.
+func New(address common.Address, caller ethereum.ContractCaller, from common.Address) *EntryPoint {
+	return &EntryPoint{address: address, caller: caller, from: from}
+}
+
+// Address is the address of the EntryPoint contract.
+func (e *EntryPoint) Address() common.Address {
+	return e.address
+}
+
+// Hash asks the EntryPoint for the operation's hash, which identifies it in
+// logs.
+func (e *EntryPoint) Hash(ctx context.Context, op *UserOperation) (common.Hash, error) {
+	data, err := contractABI.Pack("getUserOpHash", op)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	out, err := e.caller.CallContract(ctx, ethereum.CallMsg{To: &e.address, Data: data}, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to retrieve user operation hash: %w", err)
+	}
+
+	vals, err := contractABI.Unpack("getUserOpHash", out)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to decode user operation hash: %w", err)
+	}
+
+	return common.Hash(vals[0].([32]byte)), nil
+}
+
+// Simulate runs handleOps with just this operation against the latest block.
+// It returns an *Invalid if the EntryPoint rejects the operation. This doesn't
+// enforce the ERC-7562 validation rules, so an operation can still fail later
+// if the state it depends on changes.
+func (e *EntryPoint) Simulate(ctx context.Context, op *UserOperation) error {
+	data, err := HandleOpsData([]*UserOperation{op}, e.from)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.caller.CallContract(ctx, ethereum.CallMsg{From: e.from, To: &e.address, Data: data}, nil)
+	if err == nil {
+		return nil
+	}
+
+	var de rpc.DataError
+	if !errors.As(err, &de) {
+		return fmt.Errorf("failed to simulate user operation: %w", err)
+	}
+
+	// A revert, then.
+	if hexData, ok := de.ErrorData().(string); ok {
+		if revert, err := hexutil.Decode(hexData); err == nil && len(revert) >= 4 {
+			for _, name := range []string{"FailedOp", "FailedOpWithRevert"} {
+				abiErr := contractABI.Errors[name]
+				if [4]byte(revert[:4]) != [4]byte(abiErr.ID[:4]) {
+					continue
+				}
+				if vals, err := abiErr.Inputs.Unpack(revert[4:]); err == nil {
+					return &Invalid{msg: fmt.Sprintf("rejected by EntryPoint: %s", vals[1])}
+				}
+			}
+		}
+	}
+
+	return &Invalid{msg: fmt.Sprintf("simulation reverted: %s", err)}
+}
+
+// Outcome is what happened to one operation in a bundle.
+type Outcome struct {
+	Success bool
+	// ActualGasCost is what the operation paid, in wei.
+	ActualGasCost *big.Int
+	// RevertReason is the revert data from the account's execution, if it
+	// failed.
+	RevertReason []byte
+	// Logs are the logs emitted while executing the operation.
+	Logs []*ethtypes.Log
+}
+
+// Outcomes reads the outcome of each operation from the logs of a handleOps
+// transaction sent to the EntryPoint at address. The logs from an operation's
+// execution are the ones between the previous operation's UserOperationEvent,
+// or BeforeExecution for the first, and its own UserOperationEvent.
+func Outcomes(address common.Address, logs []*ethtypes.Log) map[common.Hash]*Outcome {
+	var (
+		opEvent     = contractABI.Events["UserOperationEvent"]
+		revertEvent = contractABI.Events["UserOperationRevertReason"]
+		beforeEvent = contractABI.Events["BeforeExecution"]
+	)
+
+	out := make(map[common.Hash]*Outcome)
+
+	var pending []*ethtypes.Log
+	var revertReason []byte
+
+	for _, l := range logs {
+		if l.Address != address || len(l.Topics) == 0 {
+			pending = append(pending, l)
+			continue
+		}
+
+		switch l.Topics[0] {
+		case beforeEvent.ID:
+			// Validation is over. Anything before this isn't part of an
+			// execution.
+			pending = nil
+		case revertEvent.ID:
+			if vals, err := revertEvent.Inputs.NonIndexed().Unpack(l.Data); err == nil {
+				revertReason = vals[1].([]byte)
+			}
+		case opEvent.ID:
+			if len(l.Topics) < 2 {
+				continue
+			}
+
+			vals, err := opEvent.Inputs.NonIndexed().Unpack(l.Data)
+			if err != nil {
+				continue
+			}
+
+			o := &Outcome{
+				Success:       vals[1].(bool),
+				ActualGasCost: vals[2].(*big.Int),
+				Logs:          pending,
+			}
+			if !o.Success {
+				o.RevertReason = revertReason
+				o.Logs = nil
+			}
+
+			out[l.Topics[1]] = o
+
+			pending = nil
+			revertReason = nil
+		default:
+			pending = append(pending, l)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,94 @@
+package entrypoint
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	entryPoint = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+	account    = common.HexToAddress("0xf2e391f11cd1609679d03a1ac965b1d0432a7007")
+	token      = common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1")
+)
+
+func TestEncodeDecode(t *testing.T) {
+	op := &UserOperation{
+		Sender:             account,
+		Nonce:              big.NewInt(7),
+		InitCode:           []byte{},
+		CallData:           common.FromHex("0xb61d27f6"),
+		AccountGasLimits:   [32]byte{31: 1},
+		PreVerificationGas: big.NewInt(50_000),
+		GasFees:            [32]byte{15: 2, 31: 3},
+		PaymasterAndData:   []byte{},
+		Signature:          common.FromHex("0x1234"),
+	}
+
+	data, err := op.Encode()
+	require.NoError(t, err)
+
+	out, err := Decode(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, op, out)
+}
+
+func TestHandleOpsData(t *testing.T) {
+	op := &UserOperation{Sender: account, Nonce: big.NewInt(0), PreVerificationGas: big.NewInt(0)}
+
+	data, err := HandleOpsData([]*UserOperation{op, op}, account)
+	require.NoError(t, err)
+
+	// handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[],address)
+	assert.Equal(t, common.FromHex("0x765e827f"), data[:4])
+}
+
+func TestOutcomes(t *testing.T) {
+	opEvent := contractABI.Events["UserOperationEvent"]
+	revertEvent := contractABI.Events["UserOperationRevertReason"]
+	beforeEvent := contractABI.Events["BeforeExecution"]
+
+	hash1, hash2 := common.HexToHash("0x01"), common.HexToHash("0x02")
+
+	opLog := func(hash common.Hash, success bool, cost int64) *ethtypes.Log {
+		data, err := opEvent.Inputs.NonIndexed().Pack(big.NewInt(0), success, big.NewInt(cost), big.NewInt(0))
+		require.NoError(t, err)
+		return &ethtypes.Log{
+			Address: entryPoint,
+			Topics:  []common.Hash{opEvent.ID, hash, common.BytesToHash(account.Bytes()), {}},
+			Data:    data,
+		}
+	}
+
+	revertData, err := revertEvent.Inputs.NonIndexed().Pack(big.NewInt(0), common.FromHex("0x08c379a0"))
+	require.NoError(t, err)
+
+	deployed := &ethtypes.Log{Address: entryPoint, Topics: []common.Hash{common.HexToHash("0xd5")}}
+	transfer := &ethtypes.Log{Address: token, Topics: []common.Hash{common.HexToHash("0xdd")}}
+
+	logs := []*ethtypes.Log{
+		deployed,
+		{Address: entryPoint, Topics: []common.Hash{beforeEvent.ID}},
+		transfer,
+		opLog(hash1, true, 100),
+		{Address: entryPoint, Topics: []common.Hash{revertEvent.ID, hash2, common.BytesToHash(account.Bytes())}, Data: revertData},
+		opLog(hash2, false, 200),
+	}
+
+	out := Outcomes(entryPoint, logs)
+
+	require.Contains(t, out, hash1)
+	assert.True(t, out[hash1].Success)
+	assert.Equal(t, big.NewInt(100), out[hash1].ActualGasCost)
+	assert.Equal(t, []*ethtypes.Log{transfer}, out[hash1].Logs)
+
+	require.Contains(t, out, hash2)
+	assert.False(t, out[hash2].Success)
+	assert.Equal(t, common.FromHex("0x08c379a0"), out[hash2].RevertReason)
+	assert.Empty(t, out[hash2].Logs)
+}
@@ -0,0 +1,215 @@
+package forwarder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// This follows OpenZeppelin's ERC2771Forwarder, from version 5 of their
+// contracts.
+
+const abiJSON = `[{"inputs":[{"components":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"uint256","name":"gas","type":"uint256"},{"internalType":"uint48","name":"deadline","type":"uint48"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"bytes","name":"signature","type":"bytes"}],"internalType":"struct ERC2771Forwarder.ForwardRequestData","name":"request","type":"tuple"}],"name":"execute","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"address","name":"owner","type":"address"}],"name":"nonces","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+var contractABI = func() abi.ABI {
+	a, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+	return a
+}()
+
+var (
+	domainTypeHash  = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	requestTypeHash = crypto.Keccak256Hash([]byte("ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,uint48 deadline,bytes data)"))
+	versionHash     = crypto.Keccak256Hash([]byte("1"))
+)
+
+// Request is a call signed by From, to be made on their behalf.
+type Request struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	// Gas is the gas limit for the inner call.
+	Gas   *big.Int
+	Nonce *big.Int
+	// Deadline is the Unix time after which the forwarder refuses the request.
+	Deadline  uint64
+	Data      []byte
+	Signature []byte
+}
+
+// Invalid is the error returned for a request that the forwarder would refuse.
+type Invalid struct {
+	msg string
+}
+
+func (e *Invalid) Error() string {
+	return e.msg
+}
+
+func invalidf(format string, a ...any) *Invalid {
+	return &Invalid{msg: fmt.Sprintf(format, a...)}
+}
+
+// Forwarder checks requests for a deployed forwarder contract and builds the
+// calls that execute them.
+type Forwarder struct {
+	address         common.Address
+	domainSeparator common.Hash
+	caller          ethereum.ContractCaller
+}
+
+// New creates a Forwarder for the contract at address. name is the EIP-712
+// domain name that the contract was deployed with. The caller is used to look
+// up nonces.
+func New(address common.Address, name string, chainID *big.Int, caller ethereum.ContractCaller) *Forwarder {
+	ds := crypto.Keccak256Hash(
+		domainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(name)),
+		versionHash.Bytes(),
+		word(chainID),
+		common.LeftPadBytes(address.Bytes(), 32),
+	)
+
+	return &Forwarder{address: address, domainSeparator: ds, caller: caller}
+}
+
+// Address is the address of the forwarder contract.
+func (f *Forwarder) Address() common.Address {
+	return f.address
+}
+
+// Digest is the EIP-712 hash that From signs.
+func (f *Forwarder) Digest(req *Request) common.Hash {
+	structHash := crypto.Keccak256(
+		requestTypeHash.Bytes(),
+		common.LeftPadBytes(req.From.Bytes(), 32),
+		common.LeftPadBytes(req.To.Bytes(), 32),
+		word(req.Value),
+		word(req.Gas),
+		word(req.Nonce),
+		word(new(big.Int).SetUint64(req.Deadline)),
+		crypto.Keccak256(req.Data),
+	)
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, f.domainSeparator.Bytes(), structHash)
+}
+
+// word encodes x as a uint256, with nil as zero.
+func word(x *big.Int) []byte {
+	if x == nil {
+		return make([]byte, 32)
+	}
+	return common.LeftPadBytes(x.Bytes(), 32)
+}
+
+// Verify checks the signature, deadline and nonce of the request. It returns an
+// *Invalid if the forwarder would refuse the request. The nonce has to be the
+// signer's current one, since the forwarder won't execute any other, and we
+// can't promise to mine a later nonce after the ones before it.
+func (f *Forwarder) Verify(ctx context.Context, req *Request, now time.Time) error {
+	if req.Deadline <= uint64(now.Unix()) {
+		return invalidf("deadline %s has passed", time.Unix(int64(req.Deadline), 0).UTC().Format(time.RFC3339))
+	}
+
+	if len(req.Signature) != crypto.SignatureLength {
+		return invalidf("signature is %d bytes, should be %d", len(req.Signature), crypto.SignatureLength)
+	}
+
+	// The contract takes the recovery id as 27 or 28, but crypto wants 0 or 1.
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, req.Signature)
+
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(sig[crypto.RecoveryIDOffset], r, s, true) {
+		return invalidf("malformed signature")
+	}
+
+	pub, err := crypto.SigToPub(f.Digest(req).Bytes(), sig)
+	if err != nil {
+		return invalidf("couldn't recover signer: %v", err)
+	}
+
+	if signer := crypto.PubkeyToAddress(*pub); signer != req.From {
+		return invalidf("signed by %s, not %s", signer, req.From)
+	}
+
+	nonce, err := f.nonce(ctx, req.From)
+	if err != nil {
+		return err
+	}
+
+	if req.Nonce == nil || req.Nonce.Cmp(nonce) < 0 {
+		return invalidf("nonce %d has already been used, the next is %d", req.Nonce, nonce)
+	}
+
+	if req.Nonce.Cmp(nonce) > 0 {
+		return invalidf("nonce %d is ahead of the next one, %d", req.Nonce, nonce)
+	}
+
+	return nil
+}
+
+func (f *Forwarder) nonce(ctx context.Context, owner common.Address) (*big.Int, error) {
+	data, err := contractABI.Pack("nonces", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := f.caller.CallContract(ctx, ethereum.CallMsg{To: &f.address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve forwarder nonce for %s: %w", owner, err)
+	}
+
+	vals, err := contractABI.Unpack("nonces", out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode forwarder nonce for %s: %w", owner, err)
+	}
+
+	return vals[0].(*big.Int), nil
+}
+
+// requestData mirrors ForwardRequestData in the contract. The nonce isn't
+// passed in; the contract uses its own.
+type requestData struct {
+	From      common.Address
+	To        common.Address
+	Value     *big.Int
+	Gas       *big.Int
+	Deadline  *big.Int
+	Data      []byte
+	Signature []byte
+}
+
+// ExecuteData encodes the call to execute that relays the request. The
+// transaction has to send req.Value along with it.
+func ExecuteData(req *Request) ([]byte, error) {
+	orZero := func(x *big.Int) *big.Int {
+		if x == nil {
+			return new(big.Int)
+		}
+		return x
+	}
+
+	return contractABI.Pack("execute", requestData{
+		From:      req.From,
+		To:        req.To,
+		Value:     orZero(req.Value),
+		Gas:       orZero(req.Gas),
+		Deadline:  new(big.Int).SetUint64(req.Deadline),
+		Data:      req.Data,
+		Signature: req.Signature,
+	})
+}
@@ -0,0 +1,146 @@
+package forwarder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	forwarderAddr = common.HexToAddress("0x8ab6d69308247c8f9af683436cdcf3532b56cb7b")
+	target        = common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1")
+	chainID       = big.NewInt(137)
+)
+
+// nonceCaller answers every nonces call with the same value.
+type nonceCaller struct {
+	nonce *big.Int
+}
+
+func (c *nonceCaller) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return contractABI.Methods["nonces"].Outputs.Pack(c.nonce)
+}
+
+func (c *nonceCaller) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func signedRequest(t *testing.T, f *Forwarder, nonce int64, deadline time.Time) *Request {
+	sk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	req := &Request{
+		From:     crypto.PubkeyToAddress(sk.PublicKey),
+		To:       target,
+		Value:    big.NewInt(0),
+		Gas:      big.NewInt(100_000),
+		Nonce:    big.NewInt(nonce),
+		Deadline: uint64(deadline.Unix()),
+		Data:     common.FromHex("0x7050f4c0"),
+	}
+
+	sig, err := crypto.Sign(f.Digest(req).Bytes(), sk)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+	req.Signature = sig
+
+	return req
+}
+
+func TestDigestMatchesTypedData(t *testing.T) {
+	f := New(forwarderAddr, "DIMOForwarder", chainID, nil)
+
+	req := &Request{
+		From:     common.HexToAddress("0xe261d618a959afffd53168cd07d12e37b26761db"),
+		To:       target,
+		Value:    big.NewInt(5),
+		Gas:      big.NewInt(100_000),
+		Nonce:    big.NewInt(3),
+		Deadline: 1_900_000_000,
+		Data:     common.FromHex("0x7050f4c0"),
+	}
+
+	td := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"ForwardRequest": {
+				{Name: "from", Type: "address"},
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "gas", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint48"},
+				{Name: "data", Type: "bytes"},
+			},
+		},
+		PrimaryType: "ForwardRequest",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "DIMOForwarder",
+			Version:           "1",
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: forwarderAddr.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     req.From.Hex(),
+			"to":       req.To.Hex(),
+			"value":    "5",
+			"gas":      "100000",
+			"nonce":    "3",
+			"deadline": "1900000000",
+			"data":     hexutil.Encode(req.Data),
+		},
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(td)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.BytesToHash(hash), f.Digest(req))
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	f := New(forwarderAddr, "DIMOForwarder", chainID, &nonceCaller{nonce: big.NewInt(4)})
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, f.Verify(ctx, signedRequest(t, f, 4, now.Add(time.Hour)), now))
+	})
+
+	tests := []struct {
+		name   string
+		nonce  int64
+		modify func(*Request)
+	}{
+		{"UsedNonce", 3, func(r *Request) {}},
+		{"FutureNonce", 6, func(r *Request) {}},
+		{"Expired", 4, func(r *Request) { r.Deadline = uint64(now.Add(-time.Second).Unix()) }},
+		{"Tampered", 4, func(r *Request) { r.Data = common.FromHex("0xa9059cbb") }},
+		{"ShortSignature", 4, func(r *Request) { r.Signature = r.Signature[:64] }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := signedRequest(t, f, tc.nonce, now.Add(time.Hour))
+			tc.modify(req)
+
+			var inv *Invalid
+			assert.ErrorAs(t, f.Verify(ctx, req, now), &inv)
+		})
+	}
+}
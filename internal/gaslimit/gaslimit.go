@@ -0,0 +1,339 @@
+package gaslimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	// MinSamples is the number of confirmed transactions we need to have seen
+	// for a contract function before its history counts.
+	MinSamples = 20
+	// historyPercent is the headroom we give over the most gas that the
+	// function has used or been estimated to use.
+	historyPercent = 125
+	// statsTTL is how long we hold on to statistics read from the database.
+	statsTTL = 10 * time.Minute
+)
+
+// Rule says how to turn an estimate into a gas limit. Zero values are unset.
+type Rule struct {
+	// Percent multiplies the estimate, so 150 is 1.5 times.
+	Percent uint64
+	// Buffer is added to the estimate, after the multiplier.
+	Buffer uint64
+	// Fixed is used in place of an estimate.
+	Fixed uint64
+	// Ceiling is the most gas we'll give the call. A call estimated to need
+	// more fails.
+	Ceiling uint64
+}
+
+// OverCeiling is the error for a call whose estimate is above its ceiling.
+type OverCeiling struct {
+	Estimate uint64
+	Ceiling  uint64
+}
+
+func (e *OverCeiling) Error() string {
+	return fmt.Sprintf("estimated gas usage %d is above the ceiling of %d", e.Estimate, e.Ceiling)
+}
+
+type target struct {
+	address  common.Address
+	selector string
+}
+
+// Policies hold the rules for each contract and function, and what we've seen
+// of their gas usage. A nil *Policies doubles every estimate, which is what we
+// used to do for everything.
+type Policies struct {
+	chainID int64
+	def     *Rule
+	rules   map[target]Rule
+
+	mu    sync.Mutex
+	stats map[target]cached
+}
+
+type cached struct {
+	maxGasUsed uint64
+	fetchedAt  time.Time
+}
+
+// Parse builds the policies for a chain. s is a comma-separated list of
+// entries of the form target=actions. The target is * for the default, a
+// contract address, or an address and a function selector joined by a colon.
+// The actions are separated by spaces, and are any of x1.5 to multiply, +30000
+// to add a buffer, fixed:200000 to skip estimation, and max:800000 for a
+// ceiling. For example,
+//
+//	*=max:5000000,0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1=x1.3 +20000,0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1:0xa9059cbb=fixed:65000
+//
+// The most specific matching entry wins. Rules without a multiplier, buffer or
+// fixed limit start from the default.
+func Parse(s string, chainID int64) (*Policies, error) {
+	p := &Policies{
+		chainID: chainID,
+		rules:   make(map[target]Rule),
+		stats:   make(map[target]cached),
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		rawTarget, rawActions, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid gas limit policy %q, should look like target=actions", entry)
+		}
+
+		var rule Rule
+
+		for _, action := range strings.Fields(rawActions) {
+			if err := rule.parseAction(action); err != nil {
+				return nil, fmt.Errorf("invalid gas limit policy %q: %w", entry, err)
+			}
+		}
+
+		if rule == (Rule{}) {
+			return nil, fmt.Errorf("gas limit policy %q has no actions", entry)
+		}
+
+		if rawTarget == "*" {
+			if p.def != nil {
+				return nil, fmt.Errorf("more than one default gas limit policy")
+			}
+			p.def = &rule
+			continue
+		}
+
+		t, err := parseTarget(rawTarget)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas limit policy %q: %w", entry, err)
+		}
+
+		if _, ok := p.rules[t]; ok {
+			return nil, fmt.Errorf("more than one gas limit policy for %q", rawTarget)
+		}
+
+		p.rules[t] = rule
+	}
+
+	return p, nil
+}
+
+func parseTarget(s string) (target, error) {
+	rawAddr, rawSel, hasSel := strings.Cut(s, ":")
+
+	if !common.IsHexAddress(rawAddr) {
+		return target{}, fmt.Errorf("invalid address %q", rawAddr)
+	}
+
+	t := target{address: common.HexToAddress(rawAddr)}
+
+	if hasSel {
+		sel, err := hexutil.Decode(rawSel)
+		if err != nil || len(sel) != 4 {
+			return target{}, fmt.Errorf("invalid function selector %q", rawSel)
+		}
+		t.selector = string(sel)
+	}
+
+	return t, nil
+}
+
+func (r *Rule) parseAction(a string) error {
+	switch {
+	case strings.HasPrefix(a, "x"):
+		f, err := strconv.ParseFloat(a[1:], 64)
+		if err != nil || f < 1 || f > 100 {
+			return fmt.Errorf("invalid multiplier %q", a)
+		}
+		r.Percent = uint64(math.Round(f * 100))
+	case strings.HasPrefix(a, "+"):
+		n, err := strconv.ParseUint(a[1:], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid buffer %q", a)
+		}
+		r.Buffer = n
+	case strings.HasPrefix(a, "fixed:"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(a, "fixed:"), 10, 64)
+		if err != nil || n == 0 {
+			return fmt.Errorf("invalid fixed limit %q", a)
+		}
+		r.Fixed = n
+	case strings.HasPrefix(a, "max:"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(a, "max:"), 10, 64)
+		if err != nil || n == 0 {
+			return fmt.Errorf("invalid ceiling %q", a)
+		}
+		r.Ceiling = n
+	default:
+		return fmt.Errorf("unknown action %q", a)
+	}
+	return nil
+}
+
+// targetFor is the key for a call. Deployments don't have one.
+func targetFor(to *common.Address, data []byte) (target, bool) {
+	if to == nil {
+		return target{}, false
+	}
+	t := target{address: *to}
+	if len(data) >= 4 {
+		t.selector = string(data[:4])
+	}
+	return t, true
+}
+
+// rule finds the most specific rule for the call. The zero Rule means there
+// isn't one.
+func (p *Policies) rule(to *common.Address, data []byte) Rule {
+	if p == nil {
+		return Rule{}
+	}
+
+	if t, ok := targetFor(to, data); ok {
+		if r, ok := p.rules[t]; ok {
+			return r
+		}
+		if r, ok := p.rules[target{address: t.address}]; ok {
+			return r
+		}
+	}
+
+	if p.def != nil {
+		return *p.def
+	}
+
+	return Rule{}
+}
+
+// Fixed returns the gas limit for the call if a policy fixes it, so that it
+// doesn't need estimating.
+func (p *Policies) Fixed(to *common.Address, data []byte) (uint64, bool) {
+	r := p.rule(to, data)
+	return r.Fixed, r.Fixed != 0
+}
+
+// Limit turns an estimate for the call into a gas limit. It returns an
+// *OverCeiling if a policy caps the call below its estimate.
+func (p *Policies) Limit(ctx context.Context, exec boil.ContextExecutor, to *common.Address, data []byte, estimate uint64) (uint64, error) {
+	r := p.rule(to, data)
+
+	if r.Ceiling != 0 && estimate > r.Ceiling {
+		return 0, &OverCeiling{Estimate: estimate, Ceiling: r.Ceiling}
+	}
+
+	var limit uint64
+
+	if r.Percent != 0 || r.Buffer != 0 {
+		limit = estimate
+		if r.Percent != 0 {
+			limit = limit * r.Percent / 100
+		}
+		limit += r.Buffer
+	} else {
+		var err error
+		limit, err = p.fromHistory(ctx, exec, to, data, estimate)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if r.Ceiling != 0 && limit > r.Ceiling {
+		limit = r.Ceiling
+	}
+
+	return limit, nil
+}
+
+// fromHistory is the default limit. With enough history for the call, it's a
+// quarter over the larger of the estimate and the most gas the call has used.
+// Otherwise, it's twice the estimate.
+func (p *Policies) fromHistory(ctx context.Context, exec boil.ContextExecutor, to *common.Address, data []byte, estimate uint64) (uint64, error) {
+	t, ok := targetFor(to, data)
+	if p == nil || !ok {
+		return 2 * estimate, nil
+	}
+
+	maxGasUsed, err := p.maxGasUsed(ctx, exec, t)
+	if err != nil {
+		return 0, err
+	}
+
+	if maxGasUsed == 0 {
+		return 2 * estimate, nil
+	}
+
+	return max(estimate, maxGasUsed) * historyPercent / 100, nil
+}
+
+// maxGasUsed returns the most gas a confirmed call to the target has used, or
+// zero if we haven't seen enough of them.
+func (p *Policies) maxGasUsed(ctx context.Context, exec boil.ContextExecutor, t target) (uint64, error) {
+	p.mu.Lock()
+	c, ok := p.stats[t]
+	p.mu.Unlock()
+
+	if ok && time.Since(c.fetchedAt) < statsTTL {
+		return c.maxGasUsed, nil
+	}
+
+	stat, err := models.GasUsageStats(
+		models.GasUsageStatWhere.ChainID.EQ(p.chainID),
+		models.GasUsageStatWhere.Address.EQ(t.address.Bytes()),
+		models.GasUsageStatWhere.Selector.EQ([]byte(t.selector)),
+	).One(ctx, exec)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to load gas usage for %s: %w", t.address, err)
+	}
+
+	c = cached{fetchedAt: time.Now()}
+	if stat != nil && stat.Samples >= MinSamples {
+		c.maxGasUsed = uint64(stat.MaxGasUsed)
+	}
+
+	p.mu.Lock()
+	p.stats[t] = c
+	p.mu.Unlock()
+
+	return c.maxGasUsed, nil
+}
+
+// Record adds the gas used by a confirmed call to its statistics.
+func (p *Policies) Record(ctx context.Context, exec boil.ContextExecutor, to *common.Address, data []byte, gasUsed uint64) error {
+	t, ok := targetFor(to, data)
+	if p == nil || !ok {
+		return nil
+	}
+
+	_, err := exec.ExecContext(ctx, `INSERT INTO meta_transaction_processor.gas_usage_stats AS s (chain_id, address, selector, samples, max_gas_used)
+		VALUES ($1, $2, $3, 1, $4)
+		ON CONFLICT (chain_id, address, selector) DO UPDATE SET
+			samples = s.samples + 1,
+			max_gas_used = GREATEST(s.max_gas_used, EXCLUDED.max_gas_used),
+			updated_at = CURRENT_TIMESTAMP`,
+		p.chainID, t.address.Bytes(), []byte(t.selector), int64(gasUsed),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record gas usage for %s: %w", t.address, err)
+	}
+
+	return nil
+}
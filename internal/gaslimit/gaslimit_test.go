@@ -0,0 +1,79 @@
+package gaslimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	token    = common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1")
+	other    = common.HexToAddress("0xf2e391f11cd1609679d03a1ac965b1d0432a7007")
+	transfer = common.FromHex("0xa9059cbb0000")
+	approve  = common.FromHex("0x095ea7b30000")
+)
+
+func TestParse(t *testing.T) {
+	p, err := Parse("*=max:5000000, 0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1=x1.3 +20000,0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1:0xa9059cbb=fixed:65000", 137)
+	require.NoError(t, err)
+
+	assert.Equal(t, Rule{Fixed: 65_000}, p.rule(&token, transfer))
+	assert.Equal(t, Rule{Percent: 130, Buffer: 20_000}, p.rule(&token, approve))
+	assert.Equal(t, Rule{Ceiling: 5_000_000}, p.rule(&other, transfer))
+	assert.Equal(t, Rule{Ceiling: 5_000_000}, p.rule(nil, nil))
+
+	for _, s := range []string{
+		"x1.3",
+		"*=",
+		"*=x0.5",
+		"*=+lots",
+		"*=fixed:0",
+		"*=max:0",
+		"*=double",
+		"0x1234=x2",
+		"0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1:0xa9=x2",
+		"*=x2,*=x3",
+	} {
+		_, err := Parse(s, 137)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	ctx := context.Background()
+
+	p, err := Parse("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1=x1.3 +20000 max:150000,0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1:0xa9059cbb=fixed:65000", 137)
+	require.NoError(t, err)
+
+	limit, ok := p.Fixed(&token, transfer)
+	assert.True(t, ok)
+	assert.EqualValues(t, 65_000, limit)
+
+	_, ok = p.Fixed(&token, approve)
+	assert.False(t, ok)
+
+	limit, err = p.Limit(ctx, nil, &token, approve, 100_000)
+	require.NoError(t, err)
+	assert.EqualValues(t, 150_000, limit)
+
+	limit, err = p.Limit(ctx, nil, &token, approve, 50_000)
+	require.NoError(t, err)
+	assert.EqualValues(t, 85_000, limit)
+
+	_, err = p.Limit(ctx, nil, &token, approve, 200_000)
+	var over *OverCeiling
+	assert.ErrorAs(t, err, &over)
+
+	// No policy and no database, as for deployments.
+	var none *Policies
+	limit, err = none.Limit(ctx, nil, &other, approve, 50_000)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100_000, limit)
+
+	limit, err = p.Limit(ctx, nil, nil, nil, 50_000)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100_000, limit)
+}
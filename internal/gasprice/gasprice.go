@@ -0,0 +1,283 @@
+package gasprice
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// DefaultStrategy is twice the node's suggestion.
+const DefaultStrategy = "node:200"
+
+// Pricer picks the gas price for a new transaction.
+type Pricer interface {
+	GasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// Suggester is a node client that can suggest a gas price.
+type Suggester interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// Client is the part of the node client that the pricers use.
+type Client interface {
+	Suggester
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+type node struct {
+	client  Suggester
+	percent int64
+}
+
+// NewNode prices at the given percentage of the node's suggestion.
+func NewNode(client Suggester, percent int) Pricer {
+	return &node{client: client, percent: int64(percent)}
+}
+
+func (n *node) GasPrice(ctx context.Context) (*big.Int, error) {
+	price, err := n.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve gas price estimate: %w", err)
+	}
+
+	price.Mul(price, big.NewInt(n.percent))
+	return price.Div(price, big.NewInt(100)), nil
+}
+
+type feeHistory struct {
+	client     Client
+	percentile float64
+	blocks     uint64
+}
+
+// NewFeeHistory prices from eth_feeHistory over the last blocks. The tip is the
+// median, across those blocks, of the given percentile of priority fees paid.
+// To that we add twice the next block's base fee, which leaves room for the
+// base fee to rise for a few blocks.
+func NewFeeHistory(client Client, percentile float64, blocks int) Pricer {
+	return &feeHistory{client: client, percentile: percentile, blocks: uint64(blocks)}
+}
+
+func (f *feeHistory) GasPrice(ctx context.Context) (*big.Int, error) {
+	hist, err := f.client.FeeHistory(ctx, f.blocks, nil, []float64{f.percentile})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve fee history: %w", err)
+	}
+
+	if len(hist.BaseFee) == 0 {
+		return nil, fmt.Errorf("fee history has no base fees")
+	}
+
+	var tips []*big.Int
+	for _, r := range hist.Reward {
+		if len(r) != 0 && r[0] != nil {
+			tips = append(tips, r[0])
+		}
+	}
+
+	tip := new(big.Int)
+	if len(tips) != 0 {
+		sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+		tip.Set(tips[len(tips)/2])
+	}
+
+	// The last base fee is for the block after the newest one.
+	next := hist.BaseFee[len(hist.BaseFee)-1]
+
+	return tip.Add(tip, new(big.Int).Mul(next, big.NewInt(2))), nil
+}
+
+type bounded struct {
+	Pricer
+	floor, cap *big.Int
+}
+
+// Bound keeps the prices from p between floor and cap. Either may be nil.
+func Bound(p Pricer, floor, cap *big.Int) Pricer {
+	if floor == nil && cap == nil {
+		return p
+	}
+	return &bounded{Pricer: p, floor: floor, cap: cap}
+}
+
+func (b *bounded) GasPrice(ctx context.Context) (*big.Int, error) {
+	price, err := b.Pricer.GasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.floor != nil && price.Cmp(b.floor) < 0 {
+		price = new(big.Int).Set(b.floor)
+	}
+	if b.cap != nil && price.Cmp(b.cap) > 0 {
+		price = new(big.Int).Set(b.cap)
+	}
+
+	return price, nil
+}
+
+type tier struct {
+	priority int
+	pricer   Pricer
+}
+
+// Schedule picks a pricer by request priority.
+type Schedule struct {
+	def Pricer
+	// tiers is sorted by priority, highest first.
+	tiers []tier
+}
+
+// NewSchedule uses def for every priority, except that a request at or above
+// one of the priorities in byPriority uses the pricer for the highest such
+// priority.
+func NewSchedule(def Pricer, byPriority map[int]Pricer) *Schedule {
+	s := &Schedule{def: def}
+	for p, pr := range byPriority {
+		s.tiers = append(s.tiers, tier{priority: p, pricer: pr})
+	}
+	sort.Slice(s.tiers, func(i, j int) bool { return s.tiers[i].priority > s.tiers[j].priority })
+	return s
+}
+
+// For returns the pricer for a request with the given priority.
+func (s *Schedule) For(priority int) Pricer {
+	for _, t := range s.tiers {
+		if priority >= t.priority {
+			return t.pricer
+		}
+	}
+	return s.def
+}
+
+// Parse builds a schedule from its settings.
+//
+// strategy is the default and byPriority is a comma-separated list of entries
+// of the form priority=strategy. A strategy is one of
+//
+//	node:percent                 the node's suggestion, scaled
+//	feehistory:percentile:blocks from eth_feeHistory
+//	gasstation:speed             the gas station at stationURL; speed is
+//	                             safeLow, standard or fast
+//
+// An empty strategy means node:200. floor and cap are decimal numbers of wei,
+// applied to every strategy. Empty means no bound.
+func Parse(client Client, strategy, byPriority, stationURL, floor, cap string) (*Schedule, error) {
+	if strategy == "" {
+		strategy = DefaultStrategy
+	}
+
+	floorWei, err := parseWei(floor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price floor: %w", err)
+	}
+
+	capWei, err := parseWei(cap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price cap: %w", err)
+	}
+
+	if floorWei != nil && capWei != nil && floorWei.Cmp(capWei) > 0 {
+		return nil, fmt.Errorf("gas price floor %s is above the cap %s", floorWei, capWei)
+	}
+
+	parse := func(spec string) (Pricer, error) {
+		p, err := parseStrategy(client, spec, stationURL)
+		if err != nil {
+			return nil, err
+		}
+		return Bound(p, floorWei, capWei), nil
+	}
+
+	def, err := parse(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers := make(map[int]Pricer)
+
+	for _, entry := range strings.Split(byPriority, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		rawPriority, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid gas price strategy entry %q, should look like priority=strategy", entry)
+		}
+
+		priority, err := strconv.Atoi(rawPriority)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority %q in gas price strategy entry %q", rawPriority, entry)
+		}
+
+		if _, ok := tiers[priority]; ok {
+			return nil, fmt.Errorf("priority %d has more than one gas price strategy", priority)
+		}
+
+		if tiers[priority], err = parse(spec); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewSchedule(def, tiers), nil
+}
+
+func parseStrategy(client Client, spec, stationURL string) (Pricer, error) {
+	kind, rest, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	args := strings.Split(rest, ":")
+
+	switch kind {
+	case "node":
+		percent, err := strconv.Atoi(rest)
+		if err != nil || percent <= 0 {
+			return nil, fmt.Errorf("invalid gas price strategy %q, should look like node:percent", spec)
+		}
+		return NewNode(client, percent), nil
+	case "feehistory":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("invalid gas price strategy %q, should look like feehistory:percentile:blocks", spec)
+		}
+		percentile, err := strconv.ParseFloat(args[0], 64)
+		if err != nil || percentile < 0 || percentile > 100 {
+			return nil, fmt.Errorf("invalid percentile %q in gas price strategy %q", args[0], spec)
+		}
+		blocks, err := strconv.Atoi(args[1])
+		if err != nil || blocks <= 0 {
+			return nil, fmt.Errorf("invalid block count %q in gas price strategy %q", args[1], spec)
+		}
+		return NewFeeHistory(client, percentile, blocks), nil
+	case "gasstation":
+		if stationURL == "" {
+			return nil, fmt.Errorf("gas price strategy %q needs a gas station URL", spec)
+		}
+		switch rest {
+		case SpeedSafeLow, SpeedStandard, SpeedFast:
+		default:
+			return nil, fmt.Errorf("invalid speed %q in gas price strategy %q", rest, spec)
+		}
+		return NewGasStation(stationURL, rest, stationClient), nil
+	default:
+		return nil, fmt.Errorf("unknown gas price strategy %q", spec)
+	}
+}
+
+func parseWei(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok || b.Sign() < 0 {
+		return nil, fmt.Errorf("%q should be a non-negative integer number of wei", s)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,113 @@
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	suggestion *big.Int
+	history    *ethereum.FeeHistory
+}
+
+func (c *fakeClient) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.suggestion), nil
+}
+
+func (c *fakeClient) FeeHistory(context.Context, uint64, *big.Int, []float64) (*ethereum.FeeHistory, error) {
+	return c.history, nil
+}
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9))
+}
+
+func TestNode(t *testing.T) {
+	p := NewNode(&fakeClient{suggestion: gwei(30)}, 150)
+
+	price, err := p.GasPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, gwei(45), price)
+}
+
+func TestFeeHistory(t *testing.T) {
+	c := &fakeClient{history: &ethereum.FeeHistory{
+		BaseFee: []*big.Int{gwei(10), gwei(11), gwei(12), gwei(13)},
+		Reward:  [][]*big.Int{{gwei(1)}, {gwei(5)}, {gwei(3)}},
+	}}
+
+	price, err := NewFeeHistory(c, 60, 3).GasPrice(context.Background())
+	require.NoError(t, err)
+	// Median tip of 3, plus twice the next base fee of 13.
+	assert.Equal(t, gwei(29), price)
+}
+
+func TestGasStation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"safeLow": {"maxPriorityFee": 30, "maxFee": 30.5},
+			"standard": {"maxPriorityFee": 32.25, "maxFee": 32.75},
+			"fast": {"maxPriorityFee": 40, "maxFee": 40.5},
+			"estimatedBaseFee": 0.5,
+			"blockTime": 2,
+			"blockNumber": 51234567
+		}`))
+	}))
+	defer srv.Close()
+
+	price, err := NewGasStation(srv.URL, SpeedStandard, srv.Client()).GasPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(32_750_000_000), price)
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+
+	_, err = NewGasStation(broken.URL, SpeedFast, broken.Client()).GasPrice(context.Background())
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	ctx := context.Background()
+	c := &fakeClient{suggestion: gwei(30)}
+
+	s, err := Parse(c, "node:200", "10=node:300, -5=node:100", "", "40000000000", "80000000000")
+	require.NoError(t, err)
+
+	tests := []struct {
+		priority int
+		price    *big.Int
+	}{
+		{-10, gwei(60)},
+		// 30 gwei, raised to the floor.
+		{-5, gwei(40)},
+		{0, gwei(40)},
+		{9, gwei(40)},
+		// 90 gwei, lowered to the cap.
+		{10, gwei(80)},
+		{100, gwei(80)},
+	}
+
+	for _, tc := range tests {
+		price, err := s.For(tc.priority).GasPrice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tc.price, price, "priority %d", tc.priority)
+	}
+
+	for _, bad := range []string{"node", "node:x", "feehistory:50", "gasstation:fast", "oracle:1"} {
+		_, err := Parse(c, bad, "", "", "", "")
+		assert.Error(t, err, bad)
+	}
+
+	_, err = Parse(c, "node:200", "", "", "10", "5")
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package gasprice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Speeds offered by the gas station.
+const (
+	SpeedSafeLow  = "safeLow"
+	SpeedStandard = "standard"
+	SpeedFast     = "fast"
+)
+
+// stationTimeout bounds each request to the gas station, so that a hung
+// response can't hold up a tick.
+const stationTimeout = 10 * time.Second
+
+var stationClient = &http.Client{Timeout: stationTimeout}
+
+type gasStation struct {
+	url    string
+	speed  string
+	client *http.Client
+}
+
+// NewGasStation prices from a Polygon gas station, version 2, at url. We pay
+// the maxFee for the speed.
+func NewGasStation(url, speed string, client *http.Client) Pricer {
+	return &gasStation{url: url, speed: speed, client: client}
+}
+
+// stationFees is one speed in the gas station's response. Fees are in gwei.
+type stationFees struct {
+	MaxPriorityFee float64 `json:"maxPriorityFee"`
+	MaxFee         float64 `json:"maxFee"`
+}
+
+func (g *gasStation) GasPrice(ctx context.Context) (*big.Int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gas station: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gas station returned status %d", resp.StatusCode)
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode gas station response: %w", err)
+	}
+
+	raw, ok := body[g.speed]
+	if !ok {
+		return nil, fmt.Errorf("gas station response has no %q fees", g.speed)
+	}
+
+	var fees stationFees
+	if err := json.Unmarshal(raw, &fees); err != nil {
+		return nil, fmt.Errorf("failed to decode gas station %q fees: %w", g.speed, err)
+	}
+
+	if fees.MaxFee <= 0 {
+		return nil, fmt.Errorf("gas station returned a %q max fee of %v gwei", g.speed, fees.MaxFee)
+	}
+
+	wei, _ := new(big.Float).Mul(big.NewFloat(fees.MaxFee), big.NewFloat(params.GWei)).Int(nil)
+
+	return wei, nil
+}
@@ -16,7 +16,7 @@ var (
 			Namespace: "meta_transaction_processor",
 			Name:      "tick_errors_total",
 		},
-		[]string{"walletIndex"},
+		[]string{"chain", "walletIndex"},
 	)
 
 	GRPCPanicsCount = promauto.NewCounter(prometheus.CounterOpts{
@@ -10,9 +10,11 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	status "github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,50 +41,142 @@ func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
 	return m.recorder
 }
 
+// Boosted mocks base method.
+func (m *MockProducer) Boosted(ctx context.Context, exec boil.ContextExecutor, msg *status.BoostedMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Boosted", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Boosted indicates an expected call of Boosted.
+func (mr *MockProducerMockRecorder) Boosted(ctx, exec, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Boosted", reflect.TypeOf((*MockProducer)(nil).Boosted), ctx, exec, msg)
+}
+
 // Confirmed mocks base method.
-func (m *MockProducer) Confirmed(msg *status.ConfirmedMsg) {
+func (m *MockProducer) Confirmed(ctx context.Context, exec boil.ContextExecutor, msg *status.ConfirmedMsg) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Confirmed", msg)
+	ret := m.ctrl.Call(m, "Confirmed", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Confirmed indicates an expected call of Confirmed.
-func (mr *MockProducerMockRecorder) Confirmed(msg any) *gomock.Call {
+func (mr *MockProducerMockRecorder) Confirmed(ctx, exec, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirmed", reflect.TypeOf((*MockProducer)(nil).Confirmed), ctx, exec, msg)
+}
+
+// Delayed mocks base method.
+func (m *MockProducer) Delayed(ctx context.Context, exec boil.ContextExecutor, msg *status.DelayedMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delayed", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delayed indicates an expected call of Delayed.
+func (mr *MockProducerMockRecorder) Delayed(ctx, exec, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delayed", reflect.TypeOf((*MockProducer)(nil).Delayed), ctx, exec, msg)
+}
+
+// Expired mocks base method.
+func (m *MockProducer) Expired(ctx context.Context, exec boil.ContextExecutor, msg *status.ExpiredMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expired", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Expired indicates an expected call of Expired.
+func (mr *MockProducerMockRecorder) Expired(ctx, exec, msg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirmed", reflect.TypeOf((*MockProducer)(nil).Confirmed), msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expired", reflect.TypeOf((*MockProducer)(nil).Expired), ctx, exec, msg)
 }
 
 // Failed mocks base method.
-func (m *MockProducer) Failed(msg *status.FailedMsg) {
+func (m *MockProducer) Failed(ctx context.Context, exec boil.ContextExecutor, msg *status.FailedMsg) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Failed", msg)
+	ret := m.ctrl.Call(m, "Failed", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Failed indicates an expected call of Failed.
-func (mr *MockProducerMockRecorder) Failed(msg any) *gomock.Call {
+func (mr *MockProducerMockRecorder) Failed(ctx, exec, msg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Failed", reflect.TypeOf((*MockProducer)(nil).Failed), msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Failed", reflect.TypeOf((*MockProducer)(nil).Failed), ctx, exec, msg)
 }
 
 // Mined mocks base method.
-func (m *MockProducer) Mined(msg *status.MinedMsg) {
+func (m *MockProducer) Mined(ctx context.Context, exec boil.ContextExecutor, msg *status.MinedMsg) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Mined", msg)
+	ret := m.ctrl.Call(m, "Mined", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Mined indicates an expected call of Mined.
-func (mr *MockProducerMockRecorder) Mined(msg any) *gomock.Call {
+func (mr *MockProducerMockRecorder) Mined(ctx, exec, msg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mined", reflect.TypeOf((*MockProducer)(nil).Mined), msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mined", reflect.TypeOf((*MockProducer)(nil).Mined), ctx, exec, msg)
+}
+
+// Rejected mocks base method.
+func (m *MockProducer) Rejected(ctx context.Context, exec boil.ContextExecutor, msg *status.RejectedMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rejected", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rejected indicates an expected call of Rejected.
+func (mr *MockProducerMockRecorder) Rejected(ctx, exec, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rejected", reflect.TypeOf((*MockProducer)(nil).Rejected), ctx, exec, msg)
+}
+
+// Reorged mocks base method.
+func (m *MockProducer) Reorged(ctx context.Context, exec boil.ContextExecutor, msg *status.ReorgedMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorged", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorged indicates an expected call of Reorged.
+func (mr *MockProducerMockRecorder) Reorged(ctx, exec, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorged", reflect.TypeOf((*MockProducer)(nil).Reorged), ctx, exec, msg)
 }
 
 // Submitted mocks base method.
-func (m *MockProducer) Submitted(msg *status.SubmittedMsg) {
+func (m *MockProducer) Submitted(ctx context.Context, exec boil.ContextExecutor, msg *status.SubmittedMsg) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Submitted", msg)
+	ret := m.ctrl.Call(m, "Submitted", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Submitted indicates an expected call of Submitted.
-func (mr *MockProducerMockRecorder) Submitted(msg any) *gomock.Call {
+func (mr *MockProducerMockRecorder) Submitted(ctx, exec, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submitted", reflect.TypeOf((*MockProducer)(nil).Submitted), ctx, exec, msg)
+}
+
+// Unmined mocks base method.
+func (m *MockProducer) Unmined(ctx context.Context, exec boil.ContextExecutor, msg *status.UnminedMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmined", ctx, exec, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmined indicates an expected call of Unmined.
+func (mr *MockProducerMockRecorder) Unmined(ctx, exec, msg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submitted", reflect.TypeOf((*MockProducer)(nil).Submitted), msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmined", reflect.TypeOf((*MockProducer)(nil).Unmined), ctx, exec, msg)
 }
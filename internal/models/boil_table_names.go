@@ -4,7 +4,17 @@
 package models
 
 var TableNames = struct {
+	ClientGasSpend          string
+	ClientRateWindows       string
+	GasUsageStats           string
 	MetaTransactionRequests string
+	OutboxEvents            string
+	ReceivedRequests        string
 }{
+	ClientGasSpend:          "client_gas_spend",
+	ClientRateWindows:       "client_rate_windows",
+	GasUsageStats:           "gas_usage_stats",
 	MetaTransactionRequests: "meta_transaction_requests",
+	OutboxEvents:            "outbox_events",
+	ReceivedRequests:        "received_requests",
 }
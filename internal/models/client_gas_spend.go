@@ -0,0 +1,1005 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// ClientGasSpend is an object representing the database table.
+type ClientGasSpend struct {
+	ClientID string        `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	Day      time.Time     `boil:"day" json:"day" toml:"day" yaml:"day"`
+	Wei      types.Decimal `boil:"wei" json:"wei" toml:"wei" yaml:"wei"`
+
+	R *clientGasSpendR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L clientGasSpendL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ClientGasSpendColumns = struct {
+	ClientID string
+	Day      string
+	Wei      string
+}{
+	ClientID: "client_id",
+	Day:      "day",
+	Wei:      "wei",
+}
+
+var ClientGasSpendTableColumns = struct {
+	ClientID string
+	Day      string
+	Wei      string
+}{
+	ClientID: "client_gas_spend.client_id",
+	Day:      "client_gas_spend.day",
+	Wei:      "client_gas_spend.wei",
+}
+
+// Generated where
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
+func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
+func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelpertypes_Decimal struct{ field string }
+
+func (w whereHelpertypes_Decimal) EQ(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_Decimal) NEQ(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_Decimal) LT(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_Decimal) LTE(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_Decimal) GT(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_Decimal) GTE(x types.Decimal) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var ClientGasSpendWhere = struct {
+	ClientID whereHelperstring
+	Day      whereHelpertime_Time
+	Wei      whereHelpertypes_Decimal
+}{
+	ClientID: whereHelperstring{field: "\"meta_transaction_processor\".\"client_gas_spend\".\"client_id\""},
+	Day:      whereHelpertime_Time{field: "\"meta_transaction_processor\".\"client_gas_spend\".\"day\""},
+	Wei:      whereHelpertypes_Decimal{field: "\"meta_transaction_processor\".\"client_gas_spend\".\"wei\""},
+}
+
+// ClientGasSpendRels is where relationship names are stored.
+var ClientGasSpendRels = struct {
+}{}
+
+// clientGasSpendR is where relationships are stored.
+type clientGasSpendR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*clientGasSpendR) NewStruct() *clientGasSpendR {
+	return &clientGasSpendR{}
+}
+
+// clientGasSpendL is where Load methods for each relationship are stored.
+type clientGasSpendL struct{}
+
+var (
+	clientGasSpendAllColumns            = []string{"client_id", "day", "wei"}
+	clientGasSpendColumnsWithoutDefault = []string{"client_id", "day", "wei"}
+	clientGasSpendColumnsWithDefault    = []string{}
+	clientGasSpendPrimaryKeyColumns     = []string{"client_id", "day"}
+	clientGasSpendGeneratedColumns      = []string{}
+)
+
+type (
+	// ClientGasSpendSlice is an alias for a slice of pointers to ClientGasSpend.
+	// This should almost always be used instead of []ClientGasSpend.
+	ClientGasSpendSlice []*ClientGasSpend
+	// ClientGasSpendHook is the signature for custom ClientGasSpend hook methods
+	ClientGasSpendHook func(context.Context, boil.ContextExecutor, *ClientGasSpend) error
+
+	clientGasSpendQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	clientGasSpendType                 = reflect.TypeOf(&ClientGasSpend{})
+	clientGasSpendMapping              = queries.MakeStructMapping(clientGasSpendType)
+	clientGasSpendPrimaryKeyMapping, _ = queries.BindMapping(clientGasSpendType, clientGasSpendMapping, clientGasSpendPrimaryKeyColumns)
+	clientGasSpendInsertCacheMut       sync.RWMutex
+	clientGasSpendInsertCache          = make(map[string]insertCache)
+	clientGasSpendUpdateCacheMut       sync.RWMutex
+	clientGasSpendUpdateCache          = make(map[string]updateCache)
+	clientGasSpendUpsertCacheMut       sync.RWMutex
+	clientGasSpendUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var clientGasSpendAfterSelectMu sync.Mutex
+var clientGasSpendAfterSelectHooks []ClientGasSpendHook
+
+var clientGasSpendBeforeInsertMu sync.Mutex
+var clientGasSpendBeforeInsertHooks []ClientGasSpendHook
+var clientGasSpendAfterInsertMu sync.Mutex
+var clientGasSpendAfterInsertHooks []ClientGasSpendHook
+
+var clientGasSpendBeforeUpdateMu sync.Mutex
+var clientGasSpendBeforeUpdateHooks []ClientGasSpendHook
+var clientGasSpendAfterUpdateMu sync.Mutex
+var clientGasSpendAfterUpdateHooks []ClientGasSpendHook
+
+var clientGasSpendBeforeDeleteMu sync.Mutex
+var clientGasSpendBeforeDeleteHooks []ClientGasSpendHook
+var clientGasSpendAfterDeleteMu sync.Mutex
+var clientGasSpendAfterDeleteHooks []ClientGasSpendHook
+
+var clientGasSpendBeforeUpsertMu sync.Mutex
+var clientGasSpendBeforeUpsertHooks []ClientGasSpendHook
+var clientGasSpendAfterUpsertMu sync.Mutex
+var clientGasSpendAfterUpsertHooks []ClientGasSpendHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ClientGasSpend) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ClientGasSpend) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ClientGasSpend) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ClientGasSpend) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ClientGasSpend) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ClientGasSpend) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ClientGasSpend) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ClientGasSpend) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ClientGasSpend) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGasSpendAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddClientGasSpendHook registers your hook function for all future operations.
+func AddClientGasSpendHook(hookPoint boil.HookPoint, clientGasSpendHook ClientGasSpendHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		clientGasSpendAfterSelectMu.Lock()
+		clientGasSpendAfterSelectHooks = append(clientGasSpendAfterSelectHooks, clientGasSpendHook)
+		clientGasSpendAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		clientGasSpendBeforeInsertMu.Lock()
+		clientGasSpendBeforeInsertHooks = append(clientGasSpendBeforeInsertHooks, clientGasSpendHook)
+		clientGasSpendBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		clientGasSpendAfterInsertMu.Lock()
+		clientGasSpendAfterInsertHooks = append(clientGasSpendAfterInsertHooks, clientGasSpendHook)
+		clientGasSpendAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		clientGasSpendBeforeUpdateMu.Lock()
+		clientGasSpendBeforeUpdateHooks = append(clientGasSpendBeforeUpdateHooks, clientGasSpendHook)
+		clientGasSpendBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		clientGasSpendAfterUpdateMu.Lock()
+		clientGasSpendAfterUpdateHooks = append(clientGasSpendAfterUpdateHooks, clientGasSpendHook)
+		clientGasSpendAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		clientGasSpendBeforeDeleteMu.Lock()
+		clientGasSpendBeforeDeleteHooks = append(clientGasSpendBeforeDeleteHooks, clientGasSpendHook)
+		clientGasSpendBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		clientGasSpendAfterDeleteMu.Lock()
+		clientGasSpendAfterDeleteHooks = append(clientGasSpendAfterDeleteHooks, clientGasSpendHook)
+		clientGasSpendAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		clientGasSpendBeforeUpsertMu.Lock()
+		clientGasSpendBeforeUpsertHooks = append(clientGasSpendBeforeUpsertHooks, clientGasSpendHook)
+		clientGasSpendBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		clientGasSpendAfterUpsertMu.Lock()
+		clientGasSpendAfterUpsertHooks = append(clientGasSpendAfterUpsertHooks, clientGasSpendHook)
+		clientGasSpendAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single clientGasSpend record from the query.
+func (q clientGasSpendQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ClientGasSpend, error) {
+	o := &ClientGasSpend{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for client_gas_spend")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ClientGasSpend records from the query.
+func (q clientGasSpendQuery) All(ctx context.Context, exec boil.ContextExecutor) (ClientGasSpendSlice, error) {
+	var o []*ClientGasSpend
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ClientGasSpend slice")
+	}
+
+	if len(clientGasSpendAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ClientGasSpend records in the query.
+func (q clientGasSpendQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count client_gas_spend rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q clientGasSpendQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if client_gas_spend exists")
+	}
+
+	return count > 0, nil
+}
+
+// ClientGasSpends retrieves all the records using an executor.
+func ClientGasSpends(mods ...qm.QueryMod) clientGasSpendQuery {
+	mods = append(mods, qm.From("\"meta_transaction_processor\".\"client_gas_spend\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"meta_transaction_processor\".\"client_gas_spend\".*"})
+	}
+
+	return clientGasSpendQuery{q}
+}
+
+// FindClientGasSpend retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindClientGasSpend(ctx context.Context, exec boil.ContextExecutor, clientID string, day time.Time, selectCols ...string) (*ClientGasSpend, error) {
+	clientGasSpendObj := &ClientGasSpend{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"meta_transaction_processor\".\"client_gas_spend\" where \"client_id\"=$1 AND \"day\"=$2", sel,
+	)
+
+	q := queries.Raw(query, clientID, day)
+
+	err := q.Bind(ctx, exec, clientGasSpendObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from client_gas_spend")
+	}
+
+	if err = clientGasSpendObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return clientGasSpendObj, err
+	}
+
+	return clientGasSpendObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ClientGasSpend) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no client_gas_spend provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientGasSpendColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	clientGasSpendInsertCacheMut.RLock()
+	cache, cached := clientGasSpendInsertCache[key]
+	clientGasSpendInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			clientGasSpendAllColumns,
+			clientGasSpendColumnsWithDefault,
+			clientGasSpendColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(clientGasSpendType, clientGasSpendMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(clientGasSpendType, clientGasSpendMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"meta_transaction_processor\".\"client_gas_spend\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"meta_transaction_processor\".\"client_gas_spend\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into client_gas_spend")
+	}
+
+	if !cached {
+		clientGasSpendInsertCacheMut.Lock()
+		clientGasSpendInsertCache[key] = cache
+		clientGasSpendInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ClientGasSpend.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ClientGasSpend) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	clientGasSpendUpdateCacheMut.RLock()
+	cache, cached := clientGasSpendUpdateCache[key]
+	clientGasSpendUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			clientGasSpendAllColumns,
+			clientGasSpendPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update client_gas_spend, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"client_gas_spend\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, clientGasSpendPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(clientGasSpendType, clientGasSpendMapping, append(wl, clientGasSpendPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update client_gas_spend row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for client_gas_spend")
+	}
+
+	if !cached {
+		clientGasSpendUpdateCacheMut.Lock()
+		clientGasSpendUpdateCache[key] = cache
+		clientGasSpendUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q clientGasSpendQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for client_gas_spend")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for client_gas_spend")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ClientGasSpendSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientGasSpendPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"client_gas_spend\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, clientGasSpendPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in clientGasSpend slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all clientGasSpend")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ClientGasSpend) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no client_gas_spend provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientGasSpendColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	clientGasSpendUpsertCacheMut.RLock()
+	cache, cached := clientGasSpendUpsertCache[key]
+	clientGasSpendUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			clientGasSpendAllColumns,
+			clientGasSpendColumnsWithDefault,
+			clientGasSpendColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			clientGasSpendAllColumns,
+			clientGasSpendPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert client_gas_spend, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(clientGasSpendAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(clientGasSpendPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert client_gas_spend, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(clientGasSpendPrimaryKeyColumns))
+			copy(conflict, clientGasSpendPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"meta_transaction_processor\".\"client_gas_spend\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(clientGasSpendType, clientGasSpendMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(clientGasSpendType, clientGasSpendMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert client_gas_spend")
+	}
+
+	if !cached {
+		clientGasSpendUpsertCacheMut.Lock()
+		clientGasSpendUpsertCache[key] = cache
+		clientGasSpendUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ClientGasSpend record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ClientGasSpend) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ClientGasSpend provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), clientGasSpendPrimaryKeyMapping)
+	sql := "DELETE FROM \"meta_transaction_processor\".\"client_gas_spend\" WHERE \"client_id\"=$1 AND \"day\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from client_gas_spend")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for client_gas_spend")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q clientGasSpendQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no clientGasSpendQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from client_gas_spend")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_gas_spend")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ClientGasSpendSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(clientGasSpendBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientGasSpendPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"meta_transaction_processor\".\"client_gas_spend\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientGasSpendPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from clientGasSpend slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_gas_spend")
+	}
+
+	if len(clientGasSpendAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ClientGasSpend) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindClientGasSpend(ctx, exec, o.ClientID, o.Day)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ClientGasSpendSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ClientGasSpendSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientGasSpendPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"meta_transaction_processor\".\"client_gas_spend\".* FROM \"meta_transaction_processor\".\"client_gas_spend\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientGasSpendPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ClientGasSpendSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ClientGasSpendExists checks if the ClientGasSpend row exists.
+func ClientGasSpendExists(ctx context.Context, exec boil.ContextExecutor, clientID string, day time.Time) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"meta_transaction_processor\".\"client_gas_spend\" where \"client_id\"=$1 AND \"day\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, clientID, day)
+	}
+	row := exec.QueryRowContext(ctx, sql, clientID, day)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if client_gas_spend exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ClientGasSpend row exists.
+func (o *ClientGasSpend) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ClientGasSpendExists(ctx, exec, o.ClientID, o.Day)
+}
@@ -0,0 +1,958 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ClientRateWindow is an object representing the database table.
+type ClientRateWindow struct {
+	ClientID    string    `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	WindowStart time.Time `boil:"window_start" json:"window_start" toml:"window_start" yaml:"window_start"`
+	Count       int       `boil:"count" json:"count" toml:"count" yaml:"count"`
+
+	R *clientRateWindowR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L clientRateWindowL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ClientRateWindowColumns = struct {
+	ClientID    string
+	WindowStart string
+	Count       string
+}{
+	ClientID:    "client_id",
+	WindowStart: "window_start",
+	Count:       "count",
+}
+
+var ClientRateWindowTableColumns = struct {
+	ClientID    string
+	WindowStart string
+	Count       string
+}{
+	ClientID:    "client_rate_windows.client_id",
+	WindowStart: "client_rate_windows.window_start",
+	Count:       "client_rate_windows.count",
+}
+
+// Generated where
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+var ClientRateWindowWhere = struct {
+	ClientID    whereHelperstring
+	WindowStart whereHelpertime_Time
+	Count       whereHelperint
+}{
+	ClientID:    whereHelperstring{field: "\"meta_transaction_processor\".\"client_rate_windows\".\"client_id\""},
+	WindowStart: whereHelpertime_Time{field: "\"meta_transaction_processor\".\"client_rate_windows\".\"window_start\""},
+	Count:       whereHelperint{field: "\"meta_transaction_processor\".\"client_rate_windows\".\"count\""},
+}
+
+// ClientRateWindowRels is where relationship names are stored.
+var ClientRateWindowRels = struct {
+}{}
+
+// clientRateWindowR is where relationships are stored.
+type clientRateWindowR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*clientRateWindowR) NewStruct() *clientRateWindowR {
+	return &clientRateWindowR{}
+}
+
+// clientRateWindowL is where Load methods for each relationship are stored.
+type clientRateWindowL struct{}
+
+var (
+	clientRateWindowAllColumns            = []string{"client_id", "window_start", "count"}
+	clientRateWindowColumnsWithoutDefault = []string{"client_id", "window_start", "count"}
+	clientRateWindowColumnsWithDefault    = []string{}
+	clientRateWindowPrimaryKeyColumns     = []string{"client_id"}
+	clientRateWindowGeneratedColumns      = []string{}
+)
+
+type (
+	// ClientRateWindowSlice is an alias for a slice of pointers to ClientRateWindow.
+	// This should almost always be used instead of []ClientRateWindow.
+	ClientRateWindowSlice []*ClientRateWindow
+	// ClientRateWindowHook is the signature for custom ClientRateWindow hook methods
+	ClientRateWindowHook func(context.Context, boil.ContextExecutor, *ClientRateWindow) error
+
+	clientRateWindowQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	clientRateWindowType                 = reflect.TypeOf(&ClientRateWindow{})
+	clientRateWindowMapping              = queries.MakeStructMapping(clientRateWindowType)
+	clientRateWindowPrimaryKeyMapping, _ = queries.BindMapping(clientRateWindowType, clientRateWindowMapping, clientRateWindowPrimaryKeyColumns)
+	clientRateWindowInsertCacheMut       sync.RWMutex
+	clientRateWindowInsertCache          = make(map[string]insertCache)
+	clientRateWindowUpdateCacheMut       sync.RWMutex
+	clientRateWindowUpdateCache          = make(map[string]updateCache)
+	clientRateWindowUpsertCacheMut       sync.RWMutex
+	clientRateWindowUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var clientRateWindowAfterSelectMu sync.Mutex
+var clientRateWindowAfterSelectHooks []ClientRateWindowHook
+
+var clientRateWindowBeforeInsertMu sync.Mutex
+var clientRateWindowBeforeInsertHooks []ClientRateWindowHook
+var clientRateWindowAfterInsertMu sync.Mutex
+var clientRateWindowAfterInsertHooks []ClientRateWindowHook
+
+var clientRateWindowBeforeUpdateMu sync.Mutex
+var clientRateWindowBeforeUpdateHooks []ClientRateWindowHook
+var clientRateWindowAfterUpdateMu sync.Mutex
+var clientRateWindowAfterUpdateHooks []ClientRateWindowHook
+
+var clientRateWindowBeforeDeleteMu sync.Mutex
+var clientRateWindowBeforeDeleteHooks []ClientRateWindowHook
+var clientRateWindowAfterDeleteMu sync.Mutex
+var clientRateWindowAfterDeleteHooks []ClientRateWindowHook
+
+var clientRateWindowBeforeUpsertMu sync.Mutex
+var clientRateWindowBeforeUpsertHooks []ClientRateWindowHook
+var clientRateWindowAfterUpsertMu sync.Mutex
+var clientRateWindowAfterUpsertHooks []ClientRateWindowHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ClientRateWindow) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ClientRateWindow) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ClientRateWindow) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ClientRateWindow) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ClientRateWindow) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ClientRateWindow) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ClientRateWindow) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ClientRateWindow) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ClientRateWindow) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientRateWindowAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddClientRateWindowHook registers your hook function for all future operations.
+func AddClientRateWindowHook(hookPoint boil.HookPoint, clientRateWindowHook ClientRateWindowHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		clientRateWindowAfterSelectMu.Lock()
+		clientRateWindowAfterSelectHooks = append(clientRateWindowAfterSelectHooks, clientRateWindowHook)
+		clientRateWindowAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		clientRateWindowBeforeInsertMu.Lock()
+		clientRateWindowBeforeInsertHooks = append(clientRateWindowBeforeInsertHooks, clientRateWindowHook)
+		clientRateWindowBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		clientRateWindowAfterInsertMu.Lock()
+		clientRateWindowAfterInsertHooks = append(clientRateWindowAfterInsertHooks, clientRateWindowHook)
+		clientRateWindowAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		clientRateWindowBeforeUpdateMu.Lock()
+		clientRateWindowBeforeUpdateHooks = append(clientRateWindowBeforeUpdateHooks, clientRateWindowHook)
+		clientRateWindowBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		clientRateWindowAfterUpdateMu.Lock()
+		clientRateWindowAfterUpdateHooks = append(clientRateWindowAfterUpdateHooks, clientRateWindowHook)
+		clientRateWindowAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		clientRateWindowBeforeDeleteMu.Lock()
+		clientRateWindowBeforeDeleteHooks = append(clientRateWindowBeforeDeleteHooks, clientRateWindowHook)
+		clientRateWindowBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		clientRateWindowAfterDeleteMu.Lock()
+		clientRateWindowAfterDeleteHooks = append(clientRateWindowAfterDeleteHooks, clientRateWindowHook)
+		clientRateWindowAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		clientRateWindowBeforeUpsertMu.Lock()
+		clientRateWindowBeforeUpsertHooks = append(clientRateWindowBeforeUpsertHooks, clientRateWindowHook)
+		clientRateWindowBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		clientRateWindowAfterUpsertMu.Lock()
+		clientRateWindowAfterUpsertHooks = append(clientRateWindowAfterUpsertHooks, clientRateWindowHook)
+		clientRateWindowAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single clientRateWindow record from the query.
+func (q clientRateWindowQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ClientRateWindow, error) {
+	o := &ClientRateWindow{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for client_rate_windows")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ClientRateWindow records from the query.
+func (q clientRateWindowQuery) All(ctx context.Context, exec boil.ContextExecutor) (ClientRateWindowSlice, error) {
+	var o []*ClientRateWindow
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ClientRateWindow slice")
+	}
+
+	if len(clientRateWindowAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ClientRateWindow records in the query.
+func (q clientRateWindowQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count client_rate_windows rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q clientRateWindowQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if client_rate_windows exists")
+	}
+
+	return count > 0, nil
+}
+
+// ClientRateWindows retrieves all the records using an executor.
+func ClientRateWindows(mods ...qm.QueryMod) clientRateWindowQuery {
+	mods = append(mods, qm.From("\"meta_transaction_processor\".\"client_rate_windows\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"meta_transaction_processor\".\"client_rate_windows\".*"})
+	}
+
+	return clientRateWindowQuery{q}
+}
+
+// FindClientRateWindow retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindClientRateWindow(ctx context.Context, exec boil.ContextExecutor, clientID string, selectCols ...string) (*ClientRateWindow, error) {
+	clientRateWindowObj := &ClientRateWindow{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"meta_transaction_processor\".\"client_rate_windows\" where \"client_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, clientID)
+
+	err := q.Bind(ctx, exec, clientRateWindowObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from client_rate_windows")
+	}
+
+	if err = clientRateWindowObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return clientRateWindowObj, err
+	}
+
+	return clientRateWindowObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ClientRateWindow) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no client_rate_windows provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientRateWindowColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	clientRateWindowInsertCacheMut.RLock()
+	cache, cached := clientRateWindowInsertCache[key]
+	clientRateWindowInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			clientRateWindowAllColumns,
+			clientRateWindowColumnsWithDefault,
+			clientRateWindowColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(clientRateWindowType, clientRateWindowMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(clientRateWindowType, clientRateWindowMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"meta_transaction_processor\".\"client_rate_windows\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"meta_transaction_processor\".\"client_rate_windows\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into client_rate_windows")
+	}
+
+	if !cached {
+		clientRateWindowInsertCacheMut.Lock()
+		clientRateWindowInsertCache[key] = cache
+		clientRateWindowInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ClientRateWindow.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ClientRateWindow) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	clientRateWindowUpdateCacheMut.RLock()
+	cache, cached := clientRateWindowUpdateCache[key]
+	clientRateWindowUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			clientRateWindowAllColumns,
+			clientRateWindowPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update client_rate_windows, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"client_rate_windows\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, clientRateWindowPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(clientRateWindowType, clientRateWindowMapping, append(wl, clientRateWindowPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update client_rate_windows row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for client_rate_windows")
+	}
+
+	if !cached {
+		clientRateWindowUpdateCacheMut.Lock()
+		clientRateWindowUpdateCache[key] = cache
+		clientRateWindowUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q clientRateWindowQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for client_rate_windows")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for client_rate_windows")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ClientRateWindowSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientRateWindowPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"client_rate_windows\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, clientRateWindowPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in clientRateWindow slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all clientRateWindow")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ClientRateWindow) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no client_rate_windows provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientRateWindowColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	clientRateWindowUpsertCacheMut.RLock()
+	cache, cached := clientRateWindowUpsertCache[key]
+	clientRateWindowUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			clientRateWindowAllColumns,
+			clientRateWindowColumnsWithDefault,
+			clientRateWindowColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			clientRateWindowAllColumns,
+			clientRateWindowPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert client_rate_windows, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(clientRateWindowAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(clientRateWindowPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert client_rate_windows, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(clientRateWindowPrimaryKeyColumns))
+			copy(conflict, clientRateWindowPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"meta_transaction_processor\".\"client_rate_windows\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(clientRateWindowType, clientRateWindowMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(clientRateWindowType, clientRateWindowMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert client_rate_windows")
+	}
+
+	if !cached {
+		clientRateWindowUpsertCacheMut.Lock()
+		clientRateWindowUpsertCache[key] = cache
+		clientRateWindowUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ClientRateWindow record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ClientRateWindow) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ClientRateWindow provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), clientRateWindowPrimaryKeyMapping)
+	sql := "DELETE FROM \"meta_transaction_processor\".\"client_rate_windows\" WHERE \"client_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from client_rate_windows")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for client_rate_windows")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q clientRateWindowQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no clientRateWindowQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from client_rate_windows")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_rate_windows")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ClientRateWindowSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(clientRateWindowBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientRateWindowPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"meta_transaction_processor\".\"client_rate_windows\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientRateWindowPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from clientRateWindow slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_rate_windows")
+	}
+
+	if len(clientRateWindowAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ClientRateWindow) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindClientRateWindow(ctx, exec, o.ClientID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ClientRateWindowSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ClientRateWindowSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientRateWindowPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"meta_transaction_processor\".\"client_rate_windows\".* FROM \"meta_transaction_processor\".\"client_rate_windows\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientRateWindowPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ClientRateWindowSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ClientRateWindowExists checks if the ClientRateWindow row exists.
+func ClientRateWindowExists(ctx context.Context, exec boil.ContextExecutor, clientID string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"meta_transaction_processor\".\"client_rate_windows\" where \"client_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, clientID)
+	}
+	row := exec.QueryRowContext(ctx, sql, clientID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if client_rate_windows exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ClientRateWindow row exists.
+func (o *ClientRateWindow) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ClientRateWindowExists(ctx, exec, o.ClientID)
+}
@@ -0,0 +1,1006 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// GasUsageStat is an object representing the database table.
+type GasUsageStat struct {
+	ChainID    int64     `boil:"chain_id" json:"chain_id" toml:"chain_id" yaml:"chain_id"`
+	Address    []byte    `boil:"address" json:"address" toml:"address" yaml:"address"`
+	Selector   []byte    `boil:"selector" json:"selector" toml:"selector" yaml:"selector"`
+	Samples    int64     `boil:"samples" json:"samples" toml:"samples" yaml:"samples"`
+	MaxGasUsed int64     `boil:"max_gas_used" json:"max_gas_used" toml:"max_gas_used" yaml:"max_gas_used"`
+	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *gasUsageStatR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L gasUsageStatL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var GasUsageStatColumns = struct {
+	ChainID    string
+	Address    string
+	Selector   string
+	Samples    string
+	MaxGasUsed string
+	UpdatedAt  string
+}{
+	ChainID:    "chain_id",
+	Address:    "address",
+	Selector:   "selector",
+	Samples:    "samples",
+	MaxGasUsed: "max_gas_used",
+	UpdatedAt:  "updated_at",
+}
+
+var GasUsageStatTableColumns = struct {
+	ChainID    string
+	Address    string
+	Selector   string
+	Samples    string
+	MaxGasUsed string
+	UpdatedAt  string
+}{
+	ChainID:    "gas_usage_stats.chain_id",
+	Address:    "gas_usage_stats.address",
+	Selector:   "gas_usage_stats.selector",
+	Samples:    "gas_usage_stats.samples",
+	MaxGasUsed: "gas_usage_stats.max_gas_used",
+	UpdatedAt:  "gas_usage_stats.updated_at",
+}
+
+// Generated where
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelper__byte struct{ field string }
+
+func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var GasUsageStatWhere = struct {
+	ChainID    whereHelperint64
+	Address    whereHelper__byte
+	Selector   whereHelper__byte
+	Samples    whereHelperint64
+	MaxGasUsed whereHelperint64
+	UpdatedAt  whereHelpertime_Time
+}{
+	ChainID:    whereHelperint64{field: "\"meta_transaction_processor\".\"gas_usage_stats\".\"chain_id\""},
+	Address:    whereHelper__byte{field: "\"meta_transaction_processor\".\"gas_usage_stats\".\"address\""},
+	Selector:   whereHelper__byte{field: "\"meta_transaction_processor\".\"gas_usage_stats\".\"selector\""},
+	Samples:    whereHelperint64{field: "\"meta_transaction_processor\".\"gas_usage_stats\".\"samples\""},
+	MaxGasUsed: whereHelperint64{field: "\"meta_transaction_processor\".\"gas_usage_stats\".\"max_gas_used\""},
+	UpdatedAt:  whereHelpertime_Time{field: "\"meta_transaction_processor\".\"gas_usage_stats\".\"updated_at\""},
+}
+
+// GasUsageStatRels is where relationship names are stored.
+var GasUsageStatRels = struct {
+}{}
+
+// gasUsageStatR is where relationships are stored.
+type gasUsageStatR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*gasUsageStatR) NewStruct() *gasUsageStatR {
+	return &gasUsageStatR{}
+}
+
+// gasUsageStatL is where Load methods for each relationship are stored.
+type gasUsageStatL struct{}
+
+var (
+	gasUsageStatAllColumns            = []string{"chain_id", "address", "selector", "samples", "max_gas_used", "updated_at"}
+	gasUsageStatColumnsWithoutDefault = []string{"chain_id", "address", "selector", "samples", "max_gas_used"}
+	gasUsageStatColumnsWithDefault    = []string{"updated_at"}
+	gasUsageStatPrimaryKeyColumns     = []string{"chain_id", "address", "selector"}
+	gasUsageStatGeneratedColumns      = []string{}
+)
+
+type (
+	// GasUsageStatSlice is an alias for a slice of pointers to GasUsageStat.
+	// This should almost always be used instead of []GasUsageStat.
+	GasUsageStatSlice []*GasUsageStat
+	// GasUsageStatHook is the signature for custom GasUsageStat hook methods
+	GasUsageStatHook func(context.Context, boil.ContextExecutor, *GasUsageStat) error
+
+	gasUsageStatQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	gasUsageStatType                 = reflect.TypeOf(&GasUsageStat{})
+	gasUsageStatMapping              = queries.MakeStructMapping(gasUsageStatType)
+	gasUsageStatPrimaryKeyMapping, _ = queries.BindMapping(gasUsageStatType, gasUsageStatMapping, gasUsageStatPrimaryKeyColumns)
+	gasUsageStatInsertCacheMut       sync.RWMutex
+	gasUsageStatInsertCache          = make(map[string]insertCache)
+	gasUsageStatUpdateCacheMut       sync.RWMutex
+	gasUsageStatUpdateCache          = make(map[string]updateCache)
+	gasUsageStatUpsertCacheMut       sync.RWMutex
+	gasUsageStatUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var gasUsageStatAfterSelectMu sync.Mutex
+var gasUsageStatAfterSelectHooks []GasUsageStatHook
+
+var gasUsageStatBeforeInsertMu sync.Mutex
+var gasUsageStatBeforeInsertHooks []GasUsageStatHook
+var gasUsageStatAfterInsertMu sync.Mutex
+var gasUsageStatAfterInsertHooks []GasUsageStatHook
+
+var gasUsageStatBeforeUpdateMu sync.Mutex
+var gasUsageStatBeforeUpdateHooks []GasUsageStatHook
+var gasUsageStatAfterUpdateMu sync.Mutex
+var gasUsageStatAfterUpdateHooks []GasUsageStatHook
+
+var gasUsageStatBeforeDeleteMu sync.Mutex
+var gasUsageStatBeforeDeleteHooks []GasUsageStatHook
+var gasUsageStatAfterDeleteMu sync.Mutex
+var gasUsageStatAfterDeleteHooks []GasUsageStatHook
+
+var gasUsageStatBeforeUpsertMu sync.Mutex
+var gasUsageStatBeforeUpsertHooks []GasUsageStatHook
+var gasUsageStatAfterUpsertMu sync.Mutex
+var gasUsageStatAfterUpsertHooks []GasUsageStatHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *GasUsageStat) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *GasUsageStat) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *GasUsageStat) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *GasUsageStat) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *GasUsageStat) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *GasUsageStat) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *GasUsageStat) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *GasUsageStat) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *GasUsageStat) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range gasUsageStatAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddGasUsageStatHook registers your hook function for all future operations.
+func AddGasUsageStatHook(hookPoint boil.HookPoint, gasUsageStatHook GasUsageStatHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		gasUsageStatAfterSelectMu.Lock()
+		gasUsageStatAfterSelectHooks = append(gasUsageStatAfterSelectHooks, gasUsageStatHook)
+		gasUsageStatAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		gasUsageStatBeforeInsertMu.Lock()
+		gasUsageStatBeforeInsertHooks = append(gasUsageStatBeforeInsertHooks, gasUsageStatHook)
+		gasUsageStatBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		gasUsageStatAfterInsertMu.Lock()
+		gasUsageStatAfterInsertHooks = append(gasUsageStatAfterInsertHooks, gasUsageStatHook)
+		gasUsageStatAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		gasUsageStatBeforeUpdateMu.Lock()
+		gasUsageStatBeforeUpdateHooks = append(gasUsageStatBeforeUpdateHooks, gasUsageStatHook)
+		gasUsageStatBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		gasUsageStatAfterUpdateMu.Lock()
+		gasUsageStatAfterUpdateHooks = append(gasUsageStatAfterUpdateHooks, gasUsageStatHook)
+		gasUsageStatAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		gasUsageStatBeforeDeleteMu.Lock()
+		gasUsageStatBeforeDeleteHooks = append(gasUsageStatBeforeDeleteHooks, gasUsageStatHook)
+		gasUsageStatBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		gasUsageStatAfterDeleteMu.Lock()
+		gasUsageStatAfterDeleteHooks = append(gasUsageStatAfterDeleteHooks, gasUsageStatHook)
+		gasUsageStatAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		gasUsageStatBeforeUpsertMu.Lock()
+		gasUsageStatBeforeUpsertHooks = append(gasUsageStatBeforeUpsertHooks, gasUsageStatHook)
+		gasUsageStatBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		gasUsageStatAfterUpsertMu.Lock()
+		gasUsageStatAfterUpsertHooks = append(gasUsageStatAfterUpsertHooks, gasUsageStatHook)
+		gasUsageStatAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single gasUsageStat record from the query.
+func (q gasUsageStatQuery) One(ctx context.Context, exec boil.ContextExecutor) (*GasUsageStat, error) {
+	o := &GasUsageStat{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for gas_usage_stats")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all GasUsageStat records from the query.
+func (q gasUsageStatQuery) All(ctx context.Context, exec boil.ContextExecutor) (GasUsageStatSlice, error) {
+	var o []*GasUsageStat
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to GasUsageStat slice")
+	}
+
+	if len(gasUsageStatAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all GasUsageStat records in the query.
+func (q gasUsageStatQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count gas_usage_stats rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q gasUsageStatQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if gas_usage_stats exists")
+	}
+
+	return count > 0, nil
+}
+
+// GasUsageStats retrieves all the records using an executor.
+func GasUsageStats(mods ...qm.QueryMod) gasUsageStatQuery {
+	mods = append(mods, qm.From("\"meta_transaction_processor\".\"gas_usage_stats\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"meta_transaction_processor\".\"gas_usage_stats\".*"})
+	}
+
+	return gasUsageStatQuery{q}
+}
+
+// FindGasUsageStat retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindGasUsageStat(ctx context.Context, exec boil.ContextExecutor, chainID int64, address []byte, selector []byte, selectCols ...string) (*GasUsageStat, error) {
+	gasUsageStatObj := &GasUsageStat{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"meta_transaction_processor\".\"gas_usage_stats\" where \"chain_id\"=$1 AND \"address\"=$2 AND \"selector\"=$3", sel,
+	)
+
+	q := queries.Raw(query, chainID, address, selector)
+
+	err := q.Bind(ctx, exec, gasUsageStatObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from gas_usage_stats")
+	}
+
+	if err = gasUsageStatObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return gasUsageStatObj, err
+	}
+
+	return gasUsageStatObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *GasUsageStat) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no gas_usage_stats provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(gasUsageStatColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	gasUsageStatInsertCacheMut.RLock()
+	cache, cached := gasUsageStatInsertCache[key]
+	gasUsageStatInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			gasUsageStatAllColumns,
+			gasUsageStatColumnsWithDefault,
+			gasUsageStatColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(gasUsageStatType, gasUsageStatMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(gasUsageStatType, gasUsageStatMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"meta_transaction_processor\".\"gas_usage_stats\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"meta_transaction_processor\".\"gas_usage_stats\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into gas_usage_stats")
+	}
+
+	if !cached {
+		gasUsageStatInsertCacheMut.Lock()
+		gasUsageStatInsertCache[key] = cache
+		gasUsageStatInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the GasUsageStat.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *GasUsageStat) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	gasUsageStatUpdateCacheMut.RLock()
+	cache, cached := gasUsageStatUpdateCache[key]
+	gasUsageStatUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			gasUsageStatAllColumns,
+			gasUsageStatPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update gas_usage_stats, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"gas_usage_stats\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, gasUsageStatPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(gasUsageStatType, gasUsageStatMapping, append(wl, gasUsageStatPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update gas_usage_stats row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for gas_usage_stats")
+	}
+
+	if !cached {
+		gasUsageStatUpdateCacheMut.Lock()
+		gasUsageStatUpdateCache[key] = cache
+		gasUsageStatUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q gasUsageStatQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for gas_usage_stats")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for gas_usage_stats")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o GasUsageStatSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), gasUsageStatPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"gas_usage_stats\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, gasUsageStatPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in gasUsageStat slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all gasUsageStat")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *GasUsageStat) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no gas_usage_stats provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(gasUsageStatColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	gasUsageStatUpsertCacheMut.RLock()
+	cache, cached := gasUsageStatUpsertCache[key]
+	gasUsageStatUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			gasUsageStatAllColumns,
+			gasUsageStatColumnsWithDefault,
+			gasUsageStatColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			gasUsageStatAllColumns,
+			gasUsageStatPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert gas_usage_stats, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(gasUsageStatAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(gasUsageStatPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert gas_usage_stats, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(gasUsageStatPrimaryKeyColumns))
+			copy(conflict, gasUsageStatPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"meta_transaction_processor\".\"gas_usage_stats\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(gasUsageStatType, gasUsageStatMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(gasUsageStatType, gasUsageStatMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert gas_usage_stats")
+	}
+
+	if !cached {
+		gasUsageStatUpsertCacheMut.Lock()
+		gasUsageStatUpsertCache[key] = cache
+		gasUsageStatUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single GasUsageStat record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *GasUsageStat) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no GasUsageStat provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), gasUsageStatPrimaryKeyMapping)
+	sql := "DELETE FROM \"meta_transaction_processor\".\"gas_usage_stats\" WHERE \"chain_id\"=$1 AND \"address\"=$2 AND \"selector\"=$3"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from gas_usage_stats")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for gas_usage_stats")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q gasUsageStatQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no gasUsageStatQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from gas_usage_stats")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for gas_usage_stats")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o GasUsageStatSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(gasUsageStatBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), gasUsageStatPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"meta_transaction_processor\".\"gas_usage_stats\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, gasUsageStatPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from gasUsageStat slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for gas_usage_stats")
+	}
+
+	if len(gasUsageStatAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *GasUsageStat) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindGasUsageStat(ctx, exec, o.ChainID, o.Address, o.Selector)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *GasUsageStatSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := GasUsageStatSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), gasUsageStatPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"meta_transaction_processor\".\"gas_usage_stats\".* FROM \"meta_transaction_processor\".\"gas_usage_stats\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, gasUsageStatPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in GasUsageStatSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// GasUsageStatExists checks if the GasUsageStat row exists.
+func GasUsageStatExists(ctx context.Context, exec boil.ContextExecutor, chainID int64, address []byte, selector []byte) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"meta_transaction_processor\".\"gas_usage_stats\" where \"chain_id\"=$1 AND \"address\"=$2 AND \"selector\"=$3 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, chainID, address, selector)
+	}
+	row := exec.QueryRowContext(ctx, sql, chainID, address, selector)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if gas_usage_stats exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the GasUsageStat row exists.
+func (o *GasUsageStat) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return GasUsageStatExists(ctx, exec, o.ChainID, o.Address, o.Selector)
+}
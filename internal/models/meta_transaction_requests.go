@@ -28,7 +28,7 @@ type MetaTransactionRequest struct {
 	ID                   string            `boil:"id" json:"id" toml:"id" yaml:"id"`
 	Nonce                types.NullDecimal `boil:"nonce" json:"nonce,omitempty" toml:"nonce" yaml:"nonce,omitempty"`
 	GasPrice             types.NullDecimal `boil:"gas_price" json:"gas_price,omitempty" toml:"gas_price" yaml:"gas_price,omitempty"`
-	To                   []byte            `boil:"to" json:"to" toml:"to" yaml:"to"`
+	To                   null.Bytes        `boil:"to" json:"to,omitempty" toml:"to" yaml:"to,omitempty"`
 	Data                 []byte            `boil:"data" json:"data" toml:"data" yaml:"data"`
 	Hash                 null.Bytes        `boil:"hash" json:"hash,omitempty" toml:"hash" yaml:"hash,omitempty"`
 	SubmittedBlockNumber types.NullDecimal `boil:"submitted_block_number" json:"submitted_block_number,omitempty" toml:"submitted_block_number" yaml:"submitted_block_number,omitempty"`
@@ -40,6 +40,20 @@ type MetaTransactionRequest struct {
 	BoostedBlockNumber   types.NullDecimal `boil:"boosted_block_number" json:"boosted_block_number,omitempty" toml:"boosted_block_number" yaml:"boosted_block_number,omitempty"`
 	BoostedBlockHash     null.Bytes        `boil:"boosted_block_hash" json:"boosted_block_hash,omitempty" toml:"boosted_block_hash" yaml:"boosted_block_hash,omitempty"`
 	WalletIndex          int               `boil:"wallet_index" json:"wallet_index" toml:"wallet_index" yaml:"wallet_index"`
+	ClientID             string            `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	Priority             int               `boil:"priority" json:"priority" toml:"priority" yaml:"priority"`
+	NotBefore            null.Time         `boil:"not_before" json:"not_before,omitempty" toml:"not_before" yaml:"not_before,omitempty"`
+	ExpiresAt            null.Time         `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+	Value                types.NullDecimal `boil:"value" json:"value,omitempty" toml:"value" yaml:"value,omitempty"`
+	GasLimit             types.NullDecimal `boil:"gas_limit" json:"gas_limit,omitempty" toml:"gas_limit" yaml:"gas_limit,omitempty"`
+	BatchIndex           null.Int          `boil:"batch_index" json:"batch_index,omitempty" toml:"batch_index" yaml:"batch_index,omitempty"`
+	UserOpHash           null.Bytes        `boil:"user_op_hash" json:"user_op_hash,omitempty" toml:"user_op_hash" yaml:"user_op_hash,omitempty"`
+	ChainID              null.Int64        `boil:"chain_id" json:"chain_id,omitempty" toml:"chain_id" yaml:"chain_id,omitempty"`
+	MaxGasPrice          types.NullDecimal `boil:"max_gas_price" json:"max_gas_price,omitempty" toml:"max_gas_price" yaml:"max_gas_price,omitempty"`
+	DelayedAt            null.Time         `boil:"delayed_at" json:"delayed_at,omitempty" toml:"delayed_at" yaml:"delayed_at,omitempty"`
+	BoostStep            int               `boil:"boost_step" json:"boost_step" toml:"boost_step" yaml:"boost_step"`
+	CanceledAt           null.Time         `boil:"canceled_at" json:"canceled_at,omitempty" toml:"canceled_at" yaml:"canceled_at,omitempty"`
+	Expectation          null.JSON         `boil:"expectation" json:"expectation,omitempty" toml:"expectation" yaml:"expectation,omitempty"`
 
 	R *metaTransactionRequestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L metaTransactionRequestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -61,6 +75,20 @@ var MetaTransactionRequestColumns = struct {
 	BoostedBlockNumber   string
 	BoostedBlockHash     string
 	WalletIndex          string
+	ClientID             string
+	Priority             string
+	NotBefore            string
+	ExpiresAt            string
+	Value                string
+	GasLimit             string
+	BatchIndex           string
+	UserOpHash           string
+	ChainID              string
+	MaxGasPrice          string
+	DelayedAt            string
+	BoostStep            string
+	CanceledAt           string
+	Expectation          string
 }{
 	ID:                   "id",
 	Nonce:                "nonce",
@@ -77,6 +105,20 @@ var MetaTransactionRequestColumns = struct {
 	BoostedBlockNumber:   "boosted_block_number",
 	BoostedBlockHash:     "boosted_block_hash",
 	WalletIndex:          "wallet_index",
+	ClientID:             "client_id",
+	Priority:             "priority",
+	NotBefore:            "not_before",
+	ExpiresAt:            "expires_at",
+	Value:                "value",
+	GasLimit:             "gas_limit",
+	BatchIndex:           "batch_index",
+	UserOpHash:           "user_op_hash",
+	ChainID:              "chain_id",
+	MaxGasPrice:          "max_gas_price",
+	DelayedAt:            "delayed_at",
+	BoostStep:            "boost_step",
+	CanceledAt:           "canceled_at",
+	Expectation:          "expectation",
 }
 
 var MetaTransactionRequestTableColumns = struct {
@@ -95,6 +137,20 @@ var MetaTransactionRequestTableColumns = struct {
 	BoostedBlockNumber   string
 	BoostedBlockHash     string
 	WalletIndex          string
+	ClientID             string
+	Priority             string
+	NotBefore            string
+	ExpiresAt            string
+	Value                string
+	GasLimit             string
+	BatchIndex           string
+	UserOpHash           string
+	ChainID              string
+	MaxGasPrice          string
+	DelayedAt            string
+	BoostStep            string
+	CanceledAt           string
+	Expectation          string
 }{
 	ID:                   "meta_transaction_requests.id",
 	Nonce:                "meta_transaction_requests.nonce",
@@ -111,37 +167,24 @@ var MetaTransactionRequestTableColumns = struct {
 	BoostedBlockNumber:   "meta_transaction_requests.boosted_block_number",
 	BoostedBlockHash:     "meta_transaction_requests.boosted_block_hash",
 	WalletIndex:          "meta_transaction_requests.wallet_index",
+	ClientID:             "meta_transaction_requests.client_id",
+	Priority:             "meta_transaction_requests.priority",
+	NotBefore:            "meta_transaction_requests.not_before",
+	ExpiresAt:            "meta_transaction_requests.expires_at",
+	Value:                "meta_transaction_requests.value",
+	GasLimit:             "meta_transaction_requests.gas_limit",
+	BatchIndex:           "meta_transaction_requests.batch_index",
+	UserOpHash:           "meta_transaction_requests.user_op_hash",
+	ChainID:              "meta_transaction_requests.chain_id",
+	MaxGasPrice:          "meta_transaction_requests.max_gas_price",
+	DelayedAt:            "meta_transaction_requests.delayed_at",
+	BoostStep:            "meta_transaction_requests.boost_step",
+	CanceledAt:           "meta_transaction_requests.canceled_at",
+	Expectation:          "meta_transaction_requests.expectation",
 }
 
 // Generated where
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpertypes_NullDecimal struct{ field string }
 
 func (w whereHelpertypes_NullDecimal) EQ(x types.NullDecimal) qm.QueryMod {
@@ -168,15 +211,6 @@ func (w whereHelpertypes_NullDecimal) IsNotNull() qm.QueryMod {
 	return qmhelper.WhereIsNotNull(w.field)
 }
 
-type whereHelper__byte struct{ field string }
-
-func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
 type whereHelpernull_Bytes struct{ field string }
 
 func (w whereHelpernull_Bytes) EQ(x null.Bytes) qm.QueryMod {
@@ -201,43 +235,58 @@ func (w whereHelpernull_Bytes) GTE(x null.Bytes) qm.QueryMod {
 func (w whereHelpernull_Bytes) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Bytes) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-type whereHelpertime_Time struct{ field string }
+type whereHelpernull_Time struct{ field string }
 
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-type whereHelperint struct{ field string }
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
 	}
 	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
 }
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
@@ -245,11 +294,76 @@ func (w whereHelperint) NIN(slice []int) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_JSON struct{ field string }
+
+func (w whereHelpernull_JSON) EQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_JSON) NEQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_JSON) LT(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_JSON) LTE(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_JSON) GT(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var MetaTransactionRequestWhere = struct {
 	ID                   whereHelperstring
 	Nonce                whereHelpertypes_NullDecimal
 	GasPrice             whereHelpertypes_NullDecimal
-	To                   whereHelper__byte
+	To                   whereHelpernull_Bytes
 	Data                 whereHelper__byte
 	Hash                 whereHelpernull_Bytes
 	SubmittedBlockNumber whereHelpertypes_NullDecimal
@@ -261,11 +375,25 @@ var MetaTransactionRequestWhere = struct {
 	BoostedBlockNumber   whereHelpertypes_NullDecimal
 	BoostedBlockHash     whereHelpernull_Bytes
 	WalletIndex          whereHelperint
+	ClientID             whereHelperstring
+	Priority             whereHelperint
+	NotBefore            whereHelpernull_Time
+	ExpiresAt            whereHelpernull_Time
+	Value                whereHelpertypes_NullDecimal
+	GasLimit             whereHelpertypes_NullDecimal
+	BatchIndex           whereHelpernull_Int
+	UserOpHash           whereHelpernull_Bytes
+	ChainID              whereHelpernull_Int64
+	MaxGasPrice          whereHelpertypes_NullDecimal
+	DelayedAt            whereHelpernull_Time
+	BoostStep            whereHelperint
+	CanceledAt           whereHelpernull_Time
+	Expectation          whereHelpernull_JSON
 }{
 	ID:                   whereHelperstring{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"id\""},
 	Nonce:                whereHelpertypes_NullDecimal{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"nonce\""},
 	GasPrice:             whereHelpertypes_NullDecimal{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"gas_price\""},
-	To:                   whereHelper__byte{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"to\""},
+	To:                   whereHelpernull_Bytes{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"to\""},
 	Data:                 whereHelper__byte{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"data\""},
 	Hash:                 whereHelpernull_Bytes{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"hash\""},
 	SubmittedBlockNumber: whereHelpertypes_NullDecimal{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"submitted_block_number\""},
@@ -277,6 +405,20 @@ var MetaTransactionRequestWhere = struct {
 	BoostedBlockNumber:   whereHelpertypes_NullDecimal{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"boosted_block_number\""},
 	BoostedBlockHash:     whereHelpernull_Bytes{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"boosted_block_hash\""},
 	WalletIndex:          whereHelperint{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"wallet_index\""},
+	ClientID:             whereHelperstring{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"client_id\""},
+	Priority:             whereHelperint{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"priority\""},
+	NotBefore:            whereHelpernull_Time{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"not_before\""},
+	ExpiresAt:            whereHelpernull_Time{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"expires_at\""},
+	Value:                whereHelpertypes_NullDecimal{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"value\""},
+	GasLimit:             whereHelpertypes_NullDecimal{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"gas_limit\""},
+	BatchIndex:           whereHelpernull_Int{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"batch_index\""},
+	UserOpHash:           whereHelpernull_Bytes{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"user_op_hash\""},
+	ChainID:              whereHelpernull_Int64{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"chain_id\""},
+	MaxGasPrice:          whereHelpertypes_NullDecimal{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"max_gas_price\""},
+	DelayedAt:            whereHelpernull_Time{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"delayed_at\""},
+	BoostStep:            whereHelperint{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"boost_step\""},
+	CanceledAt:           whereHelpernull_Time{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"canceled_at\""},
+	Expectation:          whereHelpernull_JSON{field: "\"meta_transaction_processor\".\"meta_transaction_requests\".\"expectation\""},
 }
 
 // MetaTransactionRequestRels is where relationship names are stored.
@@ -296,9 +438,9 @@ func (*metaTransactionRequestR) NewStruct() *metaTransactionRequestR {
 type metaTransactionRequestL struct{}
 
 var (
-	metaTransactionRequestAllColumns            = []string{"id", "nonce", "gas_price", "to", "data", "hash", "submitted_block_number", "submitted_block_hash", "mined_block_number", "mined_block_hash", "created_at", "updated_at", "boosted_block_number", "boosted_block_hash", "wallet_index"}
-	metaTransactionRequestColumnsWithoutDefault = []string{"id", "to", "data", "wallet_index"}
-	metaTransactionRequestColumnsWithDefault    = []string{"nonce", "gas_price", "hash", "submitted_block_number", "submitted_block_hash", "mined_block_number", "mined_block_hash", "created_at", "updated_at", "boosted_block_number", "boosted_block_hash"}
+	metaTransactionRequestAllColumns            = []string{"id", "nonce", "gas_price", "to", "data", "hash", "submitted_block_number", "submitted_block_hash", "mined_block_number", "mined_block_hash", "created_at", "updated_at", "boosted_block_number", "boosted_block_hash", "wallet_index", "client_id", "priority", "not_before", "expires_at", "value", "gas_limit", "batch_index", "user_op_hash", "chain_id", "max_gas_price", "delayed_at", "boost_step", "canceled_at", "expectation"}
+	metaTransactionRequestColumnsWithoutDefault = []string{"id", "data", "wallet_index"}
+	metaTransactionRequestColumnsWithDefault    = []string{"nonce", "gas_price", "to", "hash", "submitted_block_number", "submitted_block_hash", "mined_block_number", "mined_block_hash", "created_at", "updated_at", "boosted_block_number", "boosted_block_hash", "client_id", "priority", "not_before", "expires_at", "value", "gas_limit", "batch_index", "user_op_hash", "chain_id", "max_gas_price", "delayed_at", "boost_step", "canceled_at", "expectation"}
 	metaTransactionRequestPrimaryKeyColumns     = []string{"id"}
 	metaTransactionRequestGeneratedColumns      = []string{}
 )
@@ -0,0 +1,949 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ReceivedRequest is an object representing the database table.
+type ReceivedRequest struct {
+	ID          string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	PayloadHash []byte    `boil:"payload_hash" json:"payload_hash" toml:"payload_hash" yaml:"payload_hash"`
+	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *receivedRequestR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L receivedRequestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ReceivedRequestColumns = struct {
+	ID          string
+	PayloadHash string
+	CreatedAt   string
+}{
+	ID:          "id",
+	PayloadHash: "payload_hash",
+	CreatedAt:   "created_at",
+}
+
+var ReceivedRequestTableColumns = struct {
+	ID          string
+	PayloadHash string
+	CreatedAt   string
+}{
+	ID:          "received_requests.id",
+	PayloadHash: "received_requests.payload_hash",
+	CreatedAt:   "received_requests.created_at",
+}
+
+// Generated where
+
+var ReceivedRequestWhere = struct {
+	ID          whereHelperstring
+	PayloadHash whereHelper__byte
+	CreatedAt   whereHelpertime_Time
+}{
+	ID:          whereHelperstring{field: "\"meta_transaction_processor\".\"received_requests\".\"id\""},
+	PayloadHash: whereHelper__byte{field: "\"meta_transaction_processor\".\"received_requests\".\"payload_hash\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"meta_transaction_processor\".\"received_requests\".\"created_at\""},
+}
+
+// ReceivedRequestRels is where relationship names are stored.
+var ReceivedRequestRels = struct {
+}{}
+
+// receivedRequestR is where relationships are stored.
+type receivedRequestR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*receivedRequestR) NewStruct() *receivedRequestR {
+	return &receivedRequestR{}
+}
+
+// receivedRequestL is where Load methods for each relationship are stored.
+type receivedRequestL struct{}
+
+var (
+	receivedRequestAllColumns            = []string{"id", "payload_hash", "created_at"}
+	receivedRequestColumnsWithoutDefault = []string{"id", "payload_hash"}
+	receivedRequestColumnsWithDefault    = []string{"created_at"}
+	receivedRequestPrimaryKeyColumns     = []string{"id"}
+	receivedRequestGeneratedColumns      = []string{}
+)
+
+type (
+	// ReceivedRequestSlice is an alias for a slice of pointers to ReceivedRequest.
+	// This should almost always be used instead of []ReceivedRequest.
+	ReceivedRequestSlice []*ReceivedRequest
+	// ReceivedRequestHook is the signature for custom ReceivedRequest hook methods
+	ReceivedRequestHook func(context.Context, boil.ContextExecutor, *ReceivedRequest) error
+
+	receivedRequestQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	receivedRequestType                 = reflect.TypeOf(&ReceivedRequest{})
+	receivedRequestMapping              = queries.MakeStructMapping(receivedRequestType)
+	receivedRequestPrimaryKeyMapping, _ = queries.BindMapping(receivedRequestType, receivedRequestMapping, receivedRequestPrimaryKeyColumns)
+	receivedRequestInsertCacheMut       sync.RWMutex
+	receivedRequestInsertCache          = make(map[string]insertCache)
+	receivedRequestUpdateCacheMut       sync.RWMutex
+	receivedRequestUpdateCache          = make(map[string]updateCache)
+	receivedRequestUpsertCacheMut       sync.RWMutex
+	receivedRequestUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var receivedRequestAfterSelectMu sync.Mutex
+var receivedRequestAfterSelectHooks []ReceivedRequestHook
+
+var receivedRequestBeforeInsertMu sync.Mutex
+var receivedRequestBeforeInsertHooks []ReceivedRequestHook
+var receivedRequestAfterInsertMu sync.Mutex
+var receivedRequestAfterInsertHooks []ReceivedRequestHook
+
+var receivedRequestBeforeUpdateMu sync.Mutex
+var receivedRequestBeforeUpdateHooks []ReceivedRequestHook
+var receivedRequestAfterUpdateMu sync.Mutex
+var receivedRequestAfterUpdateHooks []ReceivedRequestHook
+
+var receivedRequestBeforeDeleteMu sync.Mutex
+var receivedRequestBeforeDeleteHooks []ReceivedRequestHook
+var receivedRequestAfterDeleteMu sync.Mutex
+var receivedRequestAfterDeleteHooks []ReceivedRequestHook
+
+var receivedRequestBeforeUpsertMu sync.Mutex
+var receivedRequestBeforeUpsertHooks []ReceivedRequestHook
+var receivedRequestAfterUpsertMu sync.Mutex
+var receivedRequestAfterUpsertHooks []ReceivedRequestHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ReceivedRequest) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ReceivedRequest) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ReceivedRequest) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ReceivedRequest) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ReceivedRequest) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ReceivedRequest) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ReceivedRequest) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ReceivedRequest) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ReceivedRequest) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range receivedRequestAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddReceivedRequestHook registers your hook function for all future operations.
+func AddReceivedRequestHook(hookPoint boil.HookPoint, receivedRequestHook ReceivedRequestHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		receivedRequestAfterSelectMu.Lock()
+		receivedRequestAfterSelectHooks = append(receivedRequestAfterSelectHooks, receivedRequestHook)
+		receivedRequestAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		receivedRequestBeforeInsertMu.Lock()
+		receivedRequestBeforeInsertHooks = append(receivedRequestBeforeInsertHooks, receivedRequestHook)
+		receivedRequestBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		receivedRequestAfterInsertMu.Lock()
+		receivedRequestAfterInsertHooks = append(receivedRequestAfterInsertHooks, receivedRequestHook)
+		receivedRequestAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		receivedRequestBeforeUpdateMu.Lock()
+		receivedRequestBeforeUpdateHooks = append(receivedRequestBeforeUpdateHooks, receivedRequestHook)
+		receivedRequestBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		receivedRequestAfterUpdateMu.Lock()
+		receivedRequestAfterUpdateHooks = append(receivedRequestAfterUpdateHooks, receivedRequestHook)
+		receivedRequestAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		receivedRequestBeforeDeleteMu.Lock()
+		receivedRequestBeforeDeleteHooks = append(receivedRequestBeforeDeleteHooks, receivedRequestHook)
+		receivedRequestBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		receivedRequestAfterDeleteMu.Lock()
+		receivedRequestAfterDeleteHooks = append(receivedRequestAfterDeleteHooks, receivedRequestHook)
+		receivedRequestAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		receivedRequestBeforeUpsertMu.Lock()
+		receivedRequestBeforeUpsertHooks = append(receivedRequestBeforeUpsertHooks, receivedRequestHook)
+		receivedRequestBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		receivedRequestAfterUpsertMu.Lock()
+		receivedRequestAfterUpsertHooks = append(receivedRequestAfterUpsertHooks, receivedRequestHook)
+		receivedRequestAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single receivedRequest record from the query.
+func (q receivedRequestQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ReceivedRequest, error) {
+	o := &ReceivedRequest{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for received_requests")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ReceivedRequest records from the query.
+func (q receivedRequestQuery) All(ctx context.Context, exec boil.ContextExecutor) (ReceivedRequestSlice, error) {
+	var o []*ReceivedRequest
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ReceivedRequest slice")
+	}
+
+	if len(receivedRequestAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ReceivedRequest records in the query.
+func (q receivedRequestQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count received_requests rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q receivedRequestQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if received_requests exists")
+	}
+
+	return count > 0, nil
+}
+
+// ReceivedRequests retrieves all the records using an executor.
+func ReceivedRequests(mods ...qm.QueryMod) receivedRequestQuery {
+	mods = append(mods, qm.From("\"meta_transaction_processor\".\"received_requests\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"meta_transaction_processor\".\"received_requests\".*"})
+	}
+
+	return receivedRequestQuery{q}
+}
+
+// FindReceivedRequest retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindReceivedRequest(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*ReceivedRequest, error) {
+	receivedRequestObj := &ReceivedRequest{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"meta_transaction_processor\".\"received_requests\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, receivedRequestObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from received_requests")
+	}
+
+	if err = receivedRequestObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return receivedRequestObj, err
+	}
+
+	return receivedRequestObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ReceivedRequest) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no received_requests provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(receivedRequestColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	receivedRequestInsertCacheMut.RLock()
+	cache, cached := receivedRequestInsertCache[key]
+	receivedRequestInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			receivedRequestAllColumns,
+			receivedRequestColumnsWithDefault,
+			receivedRequestColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(receivedRequestType, receivedRequestMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(receivedRequestType, receivedRequestMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"meta_transaction_processor\".\"received_requests\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"meta_transaction_processor\".\"received_requests\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into received_requests")
+	}
+
+	if !cached {
+		receivedRequestInsertCacheMut.Lock()
+		receivedRequestInsertCache[key] = cache
+		receivedRequestInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ReceivedRequest.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ReceivedRequest) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	receivedRequestUpdateCacheMut.RLock()
+	cache, cached := receivedRequestUpdateCache[key]
+	receivedRequestUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			receivedRequestAllColumns,
+			receivedRequestPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update received_requests, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"received_requests\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, receivedRequestPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(receivedRequestType, receivedRequestMapping, append(wl, receivedRequestPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update received_requests row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for received_requests")
+	}
+
+	if !cached {
+		receivedRequestUpdateCacheMut.Lock()
+		receivedRequestUpdateCache[key] = cache
+		receivedRequestUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q receivedRequestQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for received_requests")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for received_requests")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ReceivedRequestSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), receivedRequestPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"meta_transaction_processor\".\"received_requests\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, receivedRequestPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in receivedRequest slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all receivedRequest")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ReceivedRequest) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no received_requests provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(receivedRequestColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	receivedRequestUpsertCacheMut.RLock()
+	cache, cached := receivedRequestUpsertCache[key]
+	receivedRequestUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			receivedRequestAllColumns,
+			receivedRequestColumnsWithDefault,
+			receivedRequestColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			receivedRequestAllColumns,
+			receivedRequestPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert received_requests, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(receivedRequestAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(receivedRequestPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert received_requests, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(receivedRequestPrimaryKeyColumns))
+			copy(conflict, receivedRequestPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"meta_transaction_processor\".\"received_requests\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(receivedRequestType, receivedRequestMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(receivedRequestType, receivedRequestMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert received_requests")
+	}
+
+	if !cached {
+		receivedRequestUpsertCacheMut.Lock()
+		receivedRequestUpsertCache[key] = cache
+		receivedRequestUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ReceivedRequest record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ReceivedRequest) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ReceivedRequest provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), receivedRequestPrimaryKeyMapping)
+	sql := "DELETE FROM \"meta_transaction_processor\".\"received_requests\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from received_requests")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for received_requests")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q receivedRequestQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no receivedRequestQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from received_requests")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for received_requests")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ReceivedRequestSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(receivedRequestBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), receivedRequestPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"meta_transaction_processor\".\"received_requests\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, receivedRequestPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from receivedRequest slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for received_requests")
+	}
+
+	if len(receivedRequestAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ReceivedRequest) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindReceivedRequest(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ReceivedRequestSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ReceivedRequestSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), receivedRequestPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"meta_transaction_processor\".\"received_requests\".* FROM \"meta_transaction_processor\".\"received_requests\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, receivedRequestPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ReceivedRequestSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ReceivedRequestExists checks if the ReceivedRequest row exists.
+func ReceivedRequestExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"meta_transaction_processor\".\"received_requests\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if received_requests exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ReceivedRequest row exists.
+func (o *ReceivedRequest) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ReceivedRequestExists(ctx, exec, o.ID)
+}
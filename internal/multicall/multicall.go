@@ -0,0 +1,52 @@
+package multicall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Address is where Multicall3 is deployed on nearly every chain.
+var Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+const abiJSON = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var contractABI = func() abi.ABI {
+	a, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+	return a
+}()
+
+// Call is one call in an aggregate3 batch.
+type Call struct {
+	Target common.Address
+	// AllowFailure lets the rest of the batch go through if this call reverts.
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Result is the outcome of one call in an aggregate3 batch. If the call
+// reverted, ReturnData holds the revert data.
+type Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// PackAggregate3 encodes the calldata for an aggregate3 call.
+func PackAggregate3(calls []Call) ([]byte, error) {
+	return contractABI.Pack("aggregate3", calls)
+}
+
+// UnpackAggregate3 decodes the output of an aggregate3 call.
+func UnpackAggregate3(output []byte) ([]Result, error) {
+	vals, err := contractABI.Unpack("aggregate3", output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode aggregate3 output: %w", err)
+	}
+
+	return *abi.ConvertType(vals[0], new([]Result)).(*[]Result), nil
+}
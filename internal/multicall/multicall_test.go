@@ -0,0 +1,42 @@
+package multicall
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackAggregate3(t *testing.T) {
+	calls := []Call{
+		{Target: common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1"), AllowFailure: true, CallData: common.FromHex("0x7050f4c0")},
+		{Target: common.HexToAddress("0xe261d618a959afffd53168cd07d12e37b26761db"), AllowFailure: true, CallData: common.FromHex("0xa9059cbb")},
+	}
+
+	data, err := PackAggregate3(calls)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.FromHex("0x82ad56cb"), data[:4])
+
+	vals, err := contractABI.Methods["aggregate3"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+
+	assert.Equal(t, calls, *abi.ConvertType(vals[0], new([]Call)).(*[]Call))
+}
+
+func TestUnpackAggregate3(t *testing.T) {
+	results := []Result{
+		{Success: true, ReturnData: []byte{}},
+		{Success: false, ReturnData: common.FromHex("0x08c379a0")},
+	}
+
+	output, err := contractABI.Methods["aggregate3"].Outputs.Pack(results)
+	require.NoError(t, err)
+
+	out, err := UnpackAggregate3(output)
+	require.NoError(t, err)
+
+	assert.Equal(t, results, out)
+}
@@ -0,0 +1,180 @@
+package policy
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Request is the part of a transaction request that the policy looks at.
+type Request struct {
+	// To is nil for contract deployments.
+	To   *common.Address
+	Data []byte
+	// Value is the amount of wei to send along. Nil means none.
+	Value *big.Int
+	// GasLimit is the gas limit set by the requester. Zero means we estimate
+	// it ourselves.
+	GasLimit uint64
+}
+
+// Violation is the error returned for a request that the policy refuses.
+type Violation struct {
+	msg string
+}
+
+func (v *Violation) Error() string {
+	return v.msg
+}
+
+func violationf(format string, a ...any) *Violation {
+	return &Violation{msg: fmt.Sprintf(format, a...)}
+}
+
+type selector [4]byte
+
+// Policy decides which requests we're willing to sign and pay for. The zero
+// value allows everything.
+type Policy struct {
+	// contracts is the set of allowed destinations. If nil, any destination is
+	// allowed.
+	contracts map[common.Address]struct{}
+	// selectors maps a contract to the functions that may be called on it.
+	// Contracts without an entry may be called with any data.
+	selectors map[common.Address]map[selector]struct{}
+	// maxCalldataSize is the largest allowed data field, in bytes. Zero means
+	// no limit.
+	maxCalldataSize int
+	// maxValue is the most wei a request may send. If nil, there is no limit.
+	maxValue *big.Int
+	// maxGasLimit is the largest gas limit a request may set. Zero means no
+	// limit.
+	maxGasLimit uint64
+	// allowDeployments lets requests create contracts even when there's a
+	// contract allowlist. Without an allowlist, deployments are always allowed.
+	allowDeployments bool
+}
+
+// New builds a policy from its settings.
+//
+// allowedContracts is a comma-separated list of addresses; empty means any
+// contract. allowedSelectors is a comma-separated list of entries of the form
+// contract:selector, like 0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1:0xa9059cbb.
+// A contract with at least one entry can only be called with those selectors.
+// maxCalldataSize is in bytes, with zero meaning no limit. maxValue is a decimal
+// number of wei, with the empty string meaning no limit. maxGasLimit caps the
+// gas limits that requests set themselves, with zero meaning no limit. If
+// allowedContracts is set, contract deployments are only allowed if
+// allowDeployments is true.
+func New(allowedContracts, allowedSelectors string, maxCalldataSize int, maxValue string, maxGasLimit int, allowDeployments bool) (*Policy, error) {
+	p := &Policy{maxCalldataSize: maxCalldataSize, allowDeployments: allowDeployments}
+
+	if maxCalldataSize < 0 {
+		return nil, fmt.Errorf("maximum calldata size %d is negative", maxCalldataSize)
+	}
+
+	if maxGasLimit < 0 {
+		return nil, fmt.Errorf("maximum gas limit %d is negative", maxGasLimit)
+	}
+	p.maxGasLimit = uint64(maxGasLimit)
+
+	if maxValue != "" {
+		v, ok := new(big.Int).SetString(maxValue, 10)
+		if !ok || v.Sign() < 0 {
+			return nil, fmt.Errorf("invalid maximum value %q, should be a non-negative integer number of wei", maxValue)
+		}
+		p.maxValue = v
+	}
+
+	for _, s := range splitList(allowedContracts) {
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid contract address %q", s)
+		}
+		if p.contracts == nil {
+			p.contracts = make(map[common.Address]struct{})
+		}
+		p.contracts[common.HexToAddress(s)] = struct{}{}
+	}
+
+	for _, s := range splitList(allowedSelectors) {
+		rawAddr, rawSel, ok := strings.Cut(s, ":")
+		if !ok || !common.IsHexAddress(rawAddr) {
+			return nil, fmt.Errorf("invalid selector entry %q, should look like 0x<contract>:0x<selector>", s)
+		}
+
+		addr := common.HexToAddress(rawAddr)
+
+		if p.contracts != nil {
+			if _, ok := p.contracts[addr]; !ok {
+				return nil, fmt.Errorf("selectors given for contract %s, which is not in the allowed contract list", addr)
+			}
+		}
+
+		b, err := hexutil.Decode(rawSel)
+		if err != nil || len(b) != 4 {
+			return nil, fmt.Errorf("invalid selector %q for contract %s", rawSel, addr)
+		}
+
+		if p.selectors == nil {
+			p.selectors = make(map[common.Address]map[selector]struct{})
+		}
+		if p.selectors[addr] == nil {
+			p.selectors[addr] = make(map[selector]struct{})
+		}
+		p.selectors[addr][selector(b)] = struct{}{}
+	}
+
+	return p, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, x := range strings.Split(s, ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Check returns a *Violation if the request is not allowed.
+func (p *Policy) Check(req *Request) error {
+	if p.maxCalldataSize != 0 && len(req.Data) > p.maxCalldataSize {
+		return violationf("calldata is %d bytes, more than the maximum of %d", len(req.Data), p.maxCalldataSize)
+	}
+
+	if p.maxValue != nil && req.Value != nil && req.Value.Cmp(p.maxValue) > 0 {
+		return violationf("value %s wei is more than the maximum of %s", req.Value, p.maxValue)
+	}
+
+	if p.maxGasLimit != 0 && req.GasLimit > p.maxGasLimit {
+		return violationf("gas limit %d is more than the maximum of %d", req.GasLimit, p.maxGasLimit)
+	}
+
+	if req.To == nil {
+		if p.contracts != nil && !p.allowDeployments {
+			return violationf("contract deployments are not allowed")
+		}
+		return nil
+	}
+
+	if p.contracts != nil {
+		if _, ok := p.contracts[*req.To]; !ok {
+			return violationf("contract %s is not allowed", req.To)
+		}
+	}
+
+	if sels, ok := p.selectors[*req.To]; ok {
+		if len(req.Data) < 4 {
+			return violationf("calldata is too short to contain a function selector, and contract %s only allows specific functions", req.To)
+		}
+		if _, ok := sels[selector(req.Data[:4])]; !ok {
+			return violationf("function %s is not allowed on contract %s", hexutil.Encode(req.Data[:4]), req.To)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	registry = common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1")
+	token    = common.HexToAddress("0xe261d618a959afffd53168cd07d12e37b26761db")
+	other    = common.HexToAddress("0x8ab6d69308247c8f9af683436cdcf3532b56cb7b")
+)
+
+func TestCheck(t *testing.T) {
+	p, err := New(
+		registry.Hex()+", "+token.Hex(),
+		token.Hex()+":0xa9059cbb,"+token.Hex()+":0x095ea7b3",
+		68,
+		"1000000000000000000",
+		500_000,
+		false,
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     Request
+		allowed bool
+	}{
+		{"AnyFunctionOnRegistry", Request{To: &registry, Data: common.FromHex("0x7050f4c0")}, true},
+		{"EmptyDataOnRegistry", Request{To: &registry}, true},
+		{"AllowedSelector", Request{To: &token, Data: common.FromHex("0xa9059cbb")}, true},
+		{"SecondAllowedSelector", Request{To: &token, Data: common.FromHex("0x095ea7b3")}, true},
+		{"OtherSelector", Request{To: &token, Data: common.FromHex("0x23b872dd")}, false},
+		{"ShortData", Request{To: &token, Data: common.FromHex("0xa905")}, false},
+		{"UnlistedContract", Request{To: &other, Data: common.FromHex("0x7050f4c0")}, false},
+		{"TooLong", Request{To: &registry, Data: make([]byte, 69)}, false},
+		{"MaxLength", Request{To: &registry, Data: make([]byte, 68)}, true},
+		{"MaxValue", Request{To: &registry, Value: big.NewInt(1e18)}, true},
+		{"TooMuchValue", Request{To: &registry, Value: big.NewInt(1e18 + 1)}, false},
+		{"MaxGasLimit", Request{To: &registry, GasLimit: 500_000}, true},
+		{"GasLimitTooHigh", Request{To: &registry, GasLimit: 500_001}, false},
+		{"Deployment", Request{Data: common.FromHex("0x6080604052")}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := p.Check(&tc.req)
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				var v *Violation
+				assert.ErrorAs(t, err, &v)
+			}
+		})
+	}
+}
+
+func TestEmptyPolicyAllowsEverything(t *testing.T) {
+	p, err := New("", "", 0, "", 0, false)
+	require.NoError(t, err)
+
+	assert.NoError(t, p.Check(&Request{To: &other, Data: make([]byte, 10_000), Value: big.NewInt(1e18), GasLimit: 30_000_000}))
+	assert.NoError(t, p.Check(&Request{Data: common.FromHex("0x6080604052")}))
+}
+
+func TestAllowDeployments(t *testing.T) {
+	p, err := New(registry.Hex(), "", 0, "", 0, true)
+	require.NoError(t, err)
+
+	assert.NoError(t, p.Check(&Request{Data: common.FromHex("0x6080604052")}))
+	assert.Error(t, p.Check(&Request{To: &other}))
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		contracts string
+		selectors string
+		maxValue  string
+	}{
+		{"BadContract", "0x1234", "", ""},
+		{"MissingSelector", "", token.Hex(), ""},
+		{"ShortSelector", "", token.Hex() + ":0xa905", ""},
+		{"SelectorForUnlistedContract", registry.Hex(), token.Hex() + ":0xa9059cbb", ""},
+		{"NegativeValue", "", "", "-1"},
+		{"HexValue", "", "", "0x10"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.contracts, tc.selectors, 0, tc.maxValue, 0, false)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Limits are the quotas for a single client. Zero values mean no limit.
+type Limits struct {
+	// RequestsPerMinute is the number of requests the client may send in any
+	// calendar minute.
+	RequestsPerMinute int
+	// DailyGasBudget is the amount of wei that the client's transactions may
+	// spend in a UTC day. Nil means no limit.
+	DailyGasBudget *big.Int
+}
+
+// Quotas tracks per-client usage in Postgres and compares it to the limits. A
+// nil *Quotas imposes no limits and records nothing.
+type Quotas struct {
+	defaults  Limits
+	overrides map[string]Limits
+}
+
+// New builds the quotas from their settings.
+//
+// requestsPerMinute and dailyGasBudget are the defaults for every client, with
+// zero and the empty string meaning no limit. dailyGasBudget is a decimal number
+// of wei. overrides is a comma-separated list of entries of the form
+// client=requestsPerMinute:dailyGasBudget; either side of the colon can be left
+// empty to keep the default.
+func New(requestsPerMinute int, dailyGasBudget string, overrides string) (*Quotas, error) {
+	budget, err := parseBudget(dailyGasBudget)
+	if err != nil {
+		return nil, err
+	}
+
+	if requestsPerMinute < 0 {
+		return nil, fmt.Errorf("request rate limit %d is negative", requestsPerMinute)
+	}
+
+	q := &Quotas{
+		defaults:  Limits{RequestsPerMinute: requestsPerMinute, DailyGasBudget: budget},
+		overrides: make(map[string]Limits),
+	}
+
+	for _, entry := range strings.Split(overrides, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid quota override %q, should look like client=requestsPerMinute:dailyGasBudget", entry)
+		}
+
+		client, rawLimits := entry[:i], entry[i+1:]
+
+		rawRPM, rawBudget, ok := strings.Cut(rawLimits, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid quota override %q, should look like client=requestsPerMinute:dailyGasBudget", entry)
+		}
+
+		lim := q.defaults
+
+		if rawRPM != "" {
+			rpm, err := strconv.Atoi(rawRPM)
+			if err != nil || rpm < 0 {
+				return nil, fmt.Errorf("invalid request rate limit %q for client %q", rawRPM, client)
+			}
+			lim.RequestsPerMinute = rpm
+		}
+
+		if rawBudget != "" {
+			b, err := parseBudget(rawBudget)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gas budget for client %q: %w", client, err)
+			}
+			lim.DailyGasBudget = b
+		}
+
+		q.overrides[client] = lim
+	}
+
+	return q, nil
+}
+
+func parseBudget(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok || b.Sign() < 0 {
+		return nil, fmt.Errorf("invalid gas budget %q, should be a non-negative integer number of wei", s)
+	}
+
+	return b, nil
+}
+
+// For returns the limits that apply to the client.
+func (q *Quotas) For(client string) Limits {
+	if q == nil {
+		return Limits{}
+	}
+	if lim, ok := q.overrides[client]; ok {
+		return lim
+	}
+	return q.defaults
+}
+
+// day returns the UTC day containing t, which is what spending is keyed on.
+func day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// AllowRequest counts a request against the client's per-minute limit and says
+// whether it fits. Requests that don't fit still count.
+func (q *Quotas) AllowRequest(ctx context.Context, exec boil.ContextExecutor, client string, now time.Time) (bool, error) {
+	lim := q.For(client)
+	if lim.RequestsPerMinute == 0 {
+		return true, nil
+	}
+
+	// Fixed windows, one row per client. Entering a new minute resets the count.
+	var count int
+	err := exec.QueryRowContext(ctx, `INSERT INTO meta_transaction_processor.client_rate_windows AS w (client_id, window_start, count)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (client_id) DO UPDATE SET
+			count = CASE WHEN w.window_start = EXCLUDED.window_start THEN w.count + 1 ELSE 1 END,
+			window_start = EXCLUDED.window_start
+		RETURNING count`,
+		client, now.UTC().Truncate(time.Minute),
+	).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to count request for client %q: %w", client, err)
+	}
+
+	return count <= lim.RequestsPerMinute, nil
+}
+
+// RecordSpend adds the fee for a transaction to the client's total for the day.
+// Free transactions are recorded too, so that a budget of zero takes effect.
+func (q *Quotas) RecordSpend(ctx context.Context, exec boil.ContextExecutor, client string, fee *big.Int, now time.Time) error {
+	if q == nil || fee == nil {
+		return nil
+	}
+
+	_, err := exec.ExecContext(ctx, `INSERT INTO meta_transaction_processor.client_gas_spend AS s (client_id, day, wei)
+		VALUES ($1, $2, $3::numeric)
+		ON CONFLICT (client_id, day) DO UPDATE SET wei = s.wei + EXCLUDED.wei`,
+		client, day(now), fee.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record spending for client %q: %w", client, err)
+	}
+
+	return nil
+}
+
+// OverBudget lists the clients that have used up their gas budget for the day.
+func (q *Quotas) OverBudget(ctx context.Context, exec boil.ContextExecutor, now time.Time) ([]string, error) {
+	if q == nil {
+		return nil, nil
+	}
+
+	spends, err := models.ClientGasSpends(
+		models.ClientGasSpendWhere.Day.EQ(day(now)),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gas spending: %w", err)
+	}
+
+	var out []string
+
+	for _, s := range spends {
+		budget := q.For(s.ClientID).DailyGasBudget
+		if budget == nil {
+			continue
+		}
+
+		if s.Wei.Int(nil).Cmp(budget) >= 0 {
+			out = append(out, s.ClientID)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package quota
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	q, err := New(60, "1000000000000000000", "identity-api=120:, backfill=:5000, dimo://attestation=10:0")
+	require.NoError(t, err)
+
+	assert.Equal(t, Limits{RequestsPerMinute: 60, DailyGasBudget: big.NewInt(1e18)}, q.For("unknown"))
+	assert.Equal(t, Limits{RequestsPerMinute: 120, DailyGasBudget: big.NewInt(1e18)}, q.For("identity-api"))
+	assert.Equal(t, Limits{RequestsPerMinute: 60, DailyGasBudget: big.NewInt(5000)}, q.For("backfill"))
+	assert.Equal(t, Limits{RequestsPerMinute: 10, DailyGasBudget: big.NewInt(0)}, q.For("dimo://attestation"))
+}
+
+func TestNewUnlimited(t *testing.T) {
+	q, err := New(0, "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, Limits{}, q.For("anyone"))
+}
+
+func TestNilQuotas(t *testing.T) {
+	var q *Quotas
+	assert.Equal(t, Limits{}, q.For("anyone"))
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		rpm       int
+		budget    string
+		overrides string
+	}{
+		{"NegativeRate", -1, "", ""},
+		{"BadBudget", 0, "1e18", ""},
+		{"NegativeBudget", 0, "-5", ""},
+		{"MissingColon", 0, "", "identity-api=120"},
+		{"MissingClient", 0, "", "=120:"},
+		{"BadOverrideRate", 0, "", "identity-api=fast:"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.rpm, tc.budget, tc.overrides)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	relayBatchSize    = 100
+	relayPollInterval = time.Second
+	relayMinBackoff   = time.Second
+	relayMaxBackoff   = time.Minute
+
+	// relayLockID is the key for the Postgres advisory lock that keeps all but
+	// one relay idle. Arbitrary, but it must not collide with other locks on
+	// the database.
+	relayLockID = 0x6d7470 // "mtp"
+)
+
+var (
+	eventsPublishedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Subsystem: "status",
+		Name:      "events_published_total",
+	})
+
+	publishErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Subsystem: "status",
+		Name:      "publish_errors_total",
+	})
+)
+
+// Relay moves status events from the outbox table onto the Kafka topic.
+//
+// Events are deleted only after Kafka has acknowledged them, so nothing written
+// by a committed transaction is lost. The producer should be idempotent, which
+// takes care of duplicates from Kafka-level retries. A crash between the
+// acknowledgement and the delete does mean the event goes out again, but with
+// the same CloudEvent id, so consumers can discard it. Only one relay publishes
+// at a time, in id order. Ids are taken at insert rather than at commit, so
+// events from concurrent transactions can go out in either order. A request's
+// events are each written after the last one committed, though, so they keep
+// their order.
+type Relay struct {
+	dbs    db.Store
+	kp     sarama.SyncProducer
+	topic  string
+	logger *zerolog.Logger
+}
+
+func NewRelay(topic string, client sarama.Client, dbs db.Store, logger *zerolog.Logger) (*Relay, error) {
+	kp, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Relay{dbs: dbs, kp: kp, topic: topic, logger: logger}, nil
+}
+
+// Run publishes events until the context is canceled. After a failure it backs
+// off exponentially, retrying the same event.
+func (r *Relay) Run(ctx context.Context) {
+	backoff := relayMinBackoff
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+
+		n, err := r.publishBatch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.logger.Err(err).Msgf("Failed to relay status events, retrying in %s.", backoff)
+			timer.Reset(backoff)
+			backoff = min(2*backoff, relayMaxBackoff)
+			continue
+		}
+
+		backoff = relayMinBackoff
+
+		if n == relayBatchSize {
+			// Probably more waiting.
+			timer.Reset(0)
+		} else {
+			timer.Reset(relayPollInterval)
+		}
+	}
+}
+
+// publishBatch sends the oldest events in the outbox to Kafka and deletes the
+// ones that were acknowledged. It stops at the first failure, recording the
+// error on that event.
+func (r *Relay) publishBatch(ctx context.Context) (int, error) {
+	tx, err := r.dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", relayLockID).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("failed to take relay lock: %w", err)
+	}
+
+	if !locked {
+		// Another replica is publishing.
+		return 0, nil
+	}
+
+	events, err := models.OutboxEvents(
+		qm.OrderBy(models.OutboxEventColumns.ID+" ASC"),
+		qm.Limit(relayBatchSize),
+	).All(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load outbox events: %w", err)
+	}
+
+	var sent []int64
+	var sendErr error
+
+	for _, ev := range events {
+		_, _, err := r.kp.SendMessage(&sarama.ProducerMessage{
+			Topic: r.topic,
+			Key:   sarama.StringEncoder(ev.RequestID),
+			Value: sarama.ByteEncoder(ev.Payload),
+		})
+		if err != nil {
+			publishErrorsTotal.Inc()
+
+			ev.Attempts++
+			ev.LastError = null.StringFrom(err.Error())
+			if _, err := ev.Update(ctx, tx, boil.Whitelist(models.OutboxEventColumns.Attempts, models.OutboxEventColumns.LastError)); err != nil {
+				return 0, fmt.Errorf("failed to record publishing error: %w", err)
+			}
+
+			sendErr = fmt.Errorf("failed to publish event %d for request %s after %d attempts: %w", ev.ID, ev.RequestID, ev.Attempts, err)
+			break
+		}
+
+		sent = append(sent, ev.ID)
+	}
+
+	if len(sent) != 0 {
+		if _, err := models.OutboxEvents(models.OutboxEventWhere.ID.IN(sent)).DeleteAll(ctx, tx); err != nil {
+			return 0, fmt.Errorf("failed to delete published events: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit relay transaction: %w", err)
+	}
+
+	eventsPublishedTotal.Add(float64(len(sent)))
+
+	return len(sent), sendErr
+}
@@ -3,24 +3,37 @@ package status
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
 	"github.com/DIMO-Network/shared"
-	"github.com/IBM/sarama"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/rs/zerolog"
 	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 type SubmittedMsg struct {
 	ID   string      `json:"id"`
 	Hash common.Hash `json:"hash"`
+	// Simulation is set if we traced the transaction before sending it.
+	Simulation *Simulation `json:"simulation,omitempty"`
+}
+
+// Simulation summarizes a trace of a transaction against the pending block.
+type Simulation struct {
+	GasUsed uint64
+	// Calls is the number of call frames, including the top-level call.
+	Calls int
+	Logs  []*Log
 }
 
 type MinedMsg struct {
-	ID   string
-	Hash common.Hash
+	ID      string
+	Hash    common.Hash
+	Receipt *Receipt
 }
 
 type ConfirmedMsg struct {
@@ -28,11 +41,134 @@ type ConfirmedMsg struct {
 	Hash       common.Hash
 	Logs       []*Log
 	Successful bool
+	Receipt    *Receipt
+	// RevertData is what the transaction reverted with, if it failed and we
+	// could recover it. RevertReason is the decoded message, for reverts with
+	// a string or a panic code.
+	RevertData   []byte
+	RevertReason string
+}
+
+// BoostedMsg is sent when a submitted transaction is replaced with one that
+// has the same nonce but a higher gas price. Only one of the two can be mined.
+type BoostedMsg struct {
+	ID       string
+	OldHash  common.Hash
+	NewHash  common.Hash
+	GasPrice *big.Int
+}
+
+// UnminedMsg is sent when a transaction that we reported as mined is no longer
+// in the canonical chain. It's back in the mempool, as far as we know.
+type UnminedMsg struct {
+	ID             string
+	Hash           common.Hash
+	OldBlockNumber *big.Int
+	OldBlockHash   common.Hash
+}
+
+// ReorgedMsg is sent when a transaction that we reported as mined shows up in
+// a different block after a reorganization.
+type ReorgedMsg struct {
+	ID             string
+	Hash           common.Hash
+	OldBlockNumber *big.Int
+	OldBlockHash   common.Hash
+	NewBlockNumber *big.Int
+	NewBlockHash   common.Hash
+}
+
+// Reasons for rejecting a request at intake, or giving up on it later.
+const (
+	// ReasonDuplicate means the request was already processed. It may have
+	// been confirmed or it may have failed, but either way it won't be
+	// executed again.
+	ReasonDuplicate = "Duplicate"
+	// ReasonConflict means a request with the same id but a different payload
+	// was already received. The original request is unaffected.
+	ReasonConflict = "Conflict"
+	// ReasonInvalid means the request couldn't be parsed or failed basic
+	// validation.
+	ReasonInvalid = "Invalid"
+	// ReasonPolicy means the request isn't allowed by the relay policy.
+	ReasonPolicy = "Policy"
+	// ReasonRateLimited means the client sent more requests than its quota
+	// allows.
+	ReasonRateLimited = "RateLimited"
+	// ReasonForwardRequest means the signed forward request would be refused
+	// by the forwarder: the signature is wrong, the deadline has passed, or
+	// the nonce isn't the signer's next one.
+	ReasonForwardRequest = "ForwardRequest"
+	// ReasonUserOperation means the EntryPoint refused the user operation in
+	// simulation, or didn't emit a UserOperationEvent for it in the bundle.
+	ReasonUserOperation = "UserOperation"
+	// ReasonCanceled means we gave up on the request after following the boost
+	// escalation schedule to its end, and replaced its transaction with an empty
+	// one.
+	ReasonCanceled = "Canceled"
+	// ReasonGasLimit means a gas limit policy caps the request's transaction
+	// below its estimated gas usage.
+	ReasonGasLimit = "GasLimit"
+	// ReasonSimulation means the request's transaction failed in simulation, or
+	// didn't do what the request said it must.
+	ReasonSimulation = "Simulation"
+	// ReasonBatch means the request's call went out in a batch that was mined,
+	// but we couldn't work out whether the call succeeded.
+	ReasonBatch = "Batch"
+)
+
+// RejectedMsg is sent when a request is refused before any transaction is
+// created for it.
+type RejectedMsg struct {
+	ID      string
+	Reason  string
+	Message string
+}
+
+// ExpiredMsg is sent when a request reaches its deadline before we get around
+// to submitting it. It won't be submitted at all.
+type ExpiredMsg struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+// DelayedMsg is sent when the gas price we'd pay for a request is above its
+// maximum. The request waits for prices to come down. If it was already
+// submitted, Hash is the transaction that we've stopped boosting.
+type DelayedMsg struct {
+	ID          string
+	Hash        *common.Hash
+	GasPrice    *big.Int
+	MaxGasPrice *big.Int
 }
 
 type FailedMsg struct {
-	ID   string
+	ID string
+	// Data is the revert data, if the failure came from the chain.
 	Data []byte
+	// Reason and Message are set when we gave up on the request ourselves.
+	Reason  string
+	Message string
+	// Hash and Receipt are set when the request failed inside a batch or
+	// bundle transaction that was mined.
+	Hash    common.Hash
+	Receipt *Receipt
+}
+
+// Receipt describes where a transaction landed and what it actually cost.
+type Receipt struct {
+	BlockNumber       *big.Int
+	BlockHash         common.Hash
+	BlockTimestamp    time.Time
+	TransactionIndex  uint
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+	// Fee is the total amount paid for the transaction, in wei. This is just
+	// GasUsed times EffectiveGasPrice.
+	Fee *big.Int
+	// ContractAddress is the address of the created contract, if the
+	// transaction was a deployment.
+	ContractAddress *common.Address
 }
 
 type Log struct {
@@ -42,30 +178,80 @@ type Log struct {
 }
 
 //go:generate mockgen -source status.go -destination ../mocks/status_client.go -package mocks
+
+// Producer records status updates for requests. Each method takes the executor
+// used for the accompanying row change, so that the update is committed if and
+// only if the change is.
 type Producer interface {
-	Submitted(msg *SubmittedMsg)
-	Mined(msg *MinedMsg)
-	Confirmed(msg *ConfirmedMsg)
-	Failed(msg *FailedMsg)
+	Submitted(ctx context.Context, exec boil.ContextExecutor, msg *SubmittedMsg) error
+	Mined(ctx context.Context, exec boil.ContextExecutor, msg *MinedMsg) error
+	Confirmed(ctx context.Context, exec boil.ContextExecutor, msg *ConfirmedMsg) error
+	Failed(ctx context.Context, exec boil.ContextExecutor, msg *FailedMsg) error
+	Boosted(ctx context.Context, exec boil.ContextExecutor, msg *BoostedMsg) error
+	Unmined(ctx context.Context, exec boil.ContextExecutor, msg *UnminedMsg) error
+	Reorged(ctx context.Context, exec boil.ContextExecutor, msg *ReorgedMsg) error
+	Rejected(ctx context.Context, exec boil.ContextExecutor, msg *RejectedMsg) error
+	Expired(ctx context.Context, exec boil.ContextExecutor, msg *ExpiredMsg) error
+	Delayed(ctx context.Context, exec boil.ContextExecutor, msg *DelayedMsg) error
 }
 
-type kafkaProducer struct {
-	kp     sarama.SyncProducer
-	topic  string
-	logger *zerolog.Logger
-}
+// outboxProducer writes status updates to the outbox_events table. They are
+// published to Kafka later by a Relay.
+type outboxProducer struct{}
 
 type tx struct {
-	Hash       common.Hash `json:"hash"`
-	Successful *bool       `json:"successful,omitempty"`
-	Logs       []*Log      `json:"logs,omitempty"`
+	Hash                common.Hash     `json:"hash"`
+	PreviousHash        *common.Hash    `json:"previousHash,omitempty"`
+	GasPrice            *hexutil.Big    `json:"gasPrice,omitempty"`
+	Successful          *bool           `json:"successful,omitempty"`
+	Logs                []*Log          `json:"logs,omitempty"`
+	PreviousBlockNumber *hexutil.Big    `json:"previousBlockNumber,omitempty"`
+	PreviousBlockHash   *common.Hash    `json:"previousBlockHash,omitempty"`
+	BlockNumber         *hexutil.Big    `json:"blockNumber,omitempty"`
+	BlockHash           *common.Hash    `json:"blockHash,omitempty"`
+	BlockTimestamp      *time.Time      `json:"blockTimestamp,omitempty"`
+	TransactionIndex    *hexutil.Uint   `json:"transactionIndex,omitempty"`
+	GasUsed             *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	EffectiveGasPrice   *hexutil.Big    `json:"effectiveGasPrice,omitempty"`
+	Fee                 *hexutil.Big    `json:"fee,omitempty"`
+	ContractAddress     *common.Address `json:"contractAddress,omitempty"`
+	Simulation          *simulation     `json:"simulation,omitempty"`
+	RevertData          hexutil.Bytes   `json:"revertData,omitempty"`
+	RevertReason        string          `json:"revertReason,omitempty"`
+}
+
+type simulation struct {
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Calls   int            `json:"calls"`
+	Logs    []*Log         `json:"logs"`
+}
+
+// withReceipt fills in the block and cost fields of the transaction. A nil
+// receipt leaves them empty.
+func (t *tx) withReceipt(r *Receipt) *tx {
+	if r == nil {
+		return t
+	}
+
+	t.BlockNumber = (*hexutil.Big)(r.BlockNumber)
+	t.BlockHash = &r.BlockHash
+	t.BlockTimestamp = &r.BlockTimestamp
+	t.TransactionIndex = (*hexutil.Uint)(&r.TransactionIndex)
+	t.GasUsed = (*hexutil.Uint64)(&r.GasUsed)
+	t.EffectiveGasPrice = (*hexutil.Big)(r.EffectiveGasPrice)
+	t.Fee = (*hexutil.Big)(r.Fee)
+	t.ContractAddress = r.ContractAddress
+
+	return t
 }
 
 type reason struct {
-	Data hexutil.Bytes `json:"data"`
+	Data    *hexutil.Bytes `json:"data,omitempty"`
+	Code    string         `json:"code,omitempty"`
+	Message string         `json:"message,omitempty"`
 }
 
-// Just using the same struct for all three event types. Lazy.
+// Just using the same struct for all event types. Lazy.
 type ceData struct {
 	RequestID   string  `json:"requestId"`
 	Type        string  `json:"type"`
@@ -73,153 +259,176 @@ type ceData struct {
 	Reason      *reason `json:"reason,omitempty"`
 }
 
-func (p *kafkaProducer) Confirmed(msg *ConfirmedMsg) {
+// write wraps the data in a CloudEvent and stores it in the outbox. The event
+// id is fixed here, so it survives any retries on the way to Kafka.
+func (p *outboxProducer) write(ctx context.Context, exec boil.ContextExecutor, data ceData) error {
 	event := shared.CloudEvent[ceData]{
 		ID:          ksuid.New().String(),
 		Source:      "meta-transaction-processor",
-		Subject:     msg.ID,
+		Subject:     data.RequestID,
 		SpecVersion: "1.0",
 		Time:        time.Now(),
 		Type:        "zone.dimo.transaction.request.event",
-		Data: ceData{
-			RequestID: msg.ID,
-			Type:      "Confirmed",
-			Transaction: &tx{
-				Hash:       msg.Hash,
-				Successful: &msg.Successful,
-				Logs:       msg.Logs,
-			},
-		},
+		Data:        data,
 	}
 
 	bs, err := json.Marshal(event)
 	if err != nil {
-		p.logger.Err(err).Msg("Couldn't marshal confirmed message.")
-		return
+		return fmt.Errorf("couldn't marshal %s message: %w", data.Type, err)
 	}
 
-	_, _, err = p.kp.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: p.topic,
-			Value: sarama.ByteEncoder(bs),
-		},
-	)
+	ev := models.OutboxEvent{
+		RequestID: data.RequestID,
+		Payload:   bs,
+	}
 
-	if err != nil {
-		p.logger.Err(err).Str("requestId", msg.ID).Str("type", "Confirmed").Msg("Failed sending status update.")
+	if err := ev.Insert(ctx, exec, boil.Infer()); err != nil {
+		return fmt.Errorf("failed to store %s message: %w", data.Type, err)
 	}
+
+	return nil
 }
 
-func (p *kafkaProducer) Submitted(msg *SubmittedMsg) {
-	event := shared.CloudEvent[ceData]{
-		ID:          ksuid.New().String(),
-		Source:      "meta-transaction-processor",
-		Subject:     msg.ID,
-		SpecVersion: "1.0",
-		Time:        time.Now(),
-		Type:        "zone.dimo.transaction.request.event",
-		Data: ceData{
-			RequestID: msg.ID,
-			Type:      "Submitted",
-			Transaction: &tx{
-				Hash: msg.Hash,
-			},
-		},
-	}
+func (p *outboxProducer) Confirmed(ctx context.Context, exec boil.ContextExecutor, msg *ConfirmedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Confirmed",
+		Transaction: (&tx{
+			Hash:         msg.Hash,
+			Successful:   &msg.Successful,
+			Logs:         msg.Logs,
+			RevertData:   msg.RevertData,
+			RevertReason: msg.RevertReason,
+		}).withReceipt(msg.Receipt),
+	})
+}
 
-	bs, err := json.Marshal(event)
-	if err != nil {
-		p.logger.Err(err).Msg("Couldn't marshal sent message.")
-		return
+func newSimulation(s *Simulation) *simulation {
+	if s == nil {
+		return nil
 	}
+	return &simulation{GasUsed: hexutil.Uint64(s.GasUsed), Calls: s.Calls, Logs: s.Logs}
+}
 
-	_, _, err = p.kp.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: p.topic,
-			Value: sarama.ByteEncoder(bs),
+func (p *outboxProducer) Submitted(ctx context.Context, exec boil.ContextExecutor, msg *SubmittedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Submitted",
+		Transaction: &tx{
+			Hash:       msg.Hash,
+			Simulation: newSimulation(msg.Simulation),
 		},
-	)
+	})
+}
 
-	if err != nil {
-		p.logger.Err(err).Str("requestId", msg.ID).Str("type", "Submitted").Msg("Failed sending status update.")
-	}
+func (p *outboxProducer) Mined(ctx context.Context, exec boil.ContextExecutor, msg *MinedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Mined",
+		Transaction: (&tx{
+			Hash: msg.Hash,
+		}).withReceipt(msg.Receipt),
+	})
 }
 
-func (p *kafkaProducer) Mined(msg *MinedMsg) {
-	event := shared.CloudEvent[ceData]{
-		ID:          ksuid.New().String(),
-		Source:      "meta-transaction-processor",
-		Subject:     msg.ID,
-		SpecVersion: "1.0",
-		Time:        time.Now(),
-		Type:        "zone.dimo.transaction.request.event",
-		Data: ceData{
-			RequestID: msg.ID,
-			Type:      "Mined",
-			Transaction: &tx{
-				Hash: msg.Hash,
-			},
+func (p *outboxProducer) Failed(ctx context.Context, exec boil.ContextExecutor, msg *FailedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Failed",
+		Reason: &reason{
+			Data:    (*hexutil.Bytes)(&msg.Data),
+			Code:    msg.Reason,
+			Message: msg.Message,
 		},
-	}
+		Transaction: failedTx(msg),
+	})
+}
 
-	bs, err := json.Marshal(event)
-	if err != nil {
-		p.logger.Err(err).Msg("Couldn't marshal mined message.")
-		return
+// failedTx describes the transaction a request failed in, if there was one.
+func failedTx(msg *FailedMsg) *tx {
+	if msg.Receipt == nil {
+		return nil
 	}
+	return (&tx{Hash: msg.Hash}).withReceipt(msg.Receipt)
+}
 
-	_, _, err = p.kp.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: p.topic,
-			Value: sarama.ByteEncoder(bs),
+func (p *outboxProducer) Boosted(ctx context.Context, exec boil.ContextExecutor, msg *BoostedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Boosted",
+		Transaction: &tx{
+			Hash:         msg.NewHash,
+			PreviousHash: &msg.OldHash,
+			GasPrice:     (*hexutil.Big)(msg.GasPrice),
 		},
-	)
-
-	if err != nil {
-		p.logger.Err(err).Str("requestId", msg.ID).Str("type", "Mined").Msg("Failed sending status update.")
-	}
+	})
 }
 
-func (p *kafkaProducer) Failed(msg *FailedMsg) {
-	event := shared.CloudEvent[ceData]{
-		ID:          ksuid.New().String(),
-		Source:      "meta-transaction-processor",
-		Subject:     msg.ID,
-		SpecVersion: "1.0",
-		Time:        time.Now(),
-		Type:        "zone.dimo.transaction.request.event",
-		Data: ceData{
-			RequestID: msg.ID,
-			Type:      "Failed",
-			Reason: &reason{
-				Data: msg.Data,
-			},
+func (p *outboxProducer) Unmined(ctx context.Context, exec boil.ContextExecutor, msg *UnminedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Unmined",
+		Transaction: &tx{
+			Hash:                msg.Hash,
+			PreviousBlockNumber: (*hexutil.Big)(msg.OldBlockNumber),
+			PreviousBlockHash:   &msg.OldBlockHash,
 		},
-	}
+	})
+}
 
-	bs, err := json.Marshal(event)
-	if err != nil {
-		p.logger.Err(err).Msg("Couldn't marshal failed message.")
-		return
-	}
+func (p *outboxProducer) Reorged(ctx context.Context, exec boil.ContextExecutor, msg *ReorgedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Reorged",
+		Transaction: &tx{
+			Hash:                msg.Hash,
+			PreviousBlockNumber: (*hexutil.Big)(msg.OldBlockNumber),
+			PreviousBlockHash:   &msg.OldBlockHash,
+			BlockNumber:         (*hexutil.Big)(msg.NewBlockNumber),
+			BlockHash:           &msg.NewBlockHash,
+		},
+	})
+}
 
-	_, _, err = p.kp.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: p.topic,
-			Value: sarama.ByteEncoder(bs),
+func (p *outboxProducer) Rejected(ctx context.Context, exec boil.ContextExecutor, msg *RejectedMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Rejected",
+		Reason: &reason{
+			Code:    msg.Reason,
+			Message: msg.Message,
 		},
-	)
+	})
+}
 
-	if err != nil {
-		p.logger.Err(err).Str("requestId", msg.ID).Str("type", "Failed").Msg("Failed sending status update.")
-	}
+func (p *outboxProducer) Expired(ctx context.Context, exec boil.ContextExecutor, msg *ExpiredMsg) error {
+	return p.write(ctx, exec, ceData{
+		RequestID: msg.ID,
+		Type:      "Expired",
+		Reason: &reason{
+			Message: fmt.Sprintf("Request expired at %s before it could be submitted.", msg.ExpiresAt.UTC().Format(time.RFC3339)),
+		},
+	})
 }
 
-func NewKafka(ctx context.Context, topic string, client sarama.Client, logger *zerolog.Logger) (Producer, error) {
-	kp, err := sarama.NewSyncProducerFromClient(client)
-	if err != nil {
-		return nil, err
+func (p *outboxProducer) Delayed(ctx context.Context, exec boil.ContextExecutor, msg *DelayedMsg) error {
+	var t *tx
+	if msg.Hash != nil {
+		t = &tx{Hash: *msg.Hash}
 	}
 
-	return &kafkaProducer{kp: kp, topic: topic, logger: logger}, nil
+	return p.write(ctx, exec, ceData{
+		RequestID:   msg.ID,
+		Type:        "Delayed",
+		Transaction: t,
+		Reason: &reason{
+			Message: fmt.Sprintf("Gas price %s wei is above the maximum of %s wei.", msg.GasPrice, msg.MaxGasPrice),
+		},
+	})
+}
+
+// NewOutbox creates a Producer that stores status updates in the database, to
+// be picked up by a Relay.
+func NewOutbox() Producer {
+	return &outboxProducer{}
 }
@@ -0,0 +1,37 @@
+package testcontract
+
+import "github.com/ethereum/go-ethereum/common"
+
+// MulticallCode is the runtime code of a stand-in for Multicall3 that only
+// knows aggregate3, for putting in the genesis of a simulated chain. It ignores
+// the selector, treats any calldata as the arguments to aggregate3 and doesn't
+// take value. Assembled from:
+//
+//	PUSH1 0x04 CALLDATALOAD PUSH1 0x04 ADD    ; offset of the calls array
+//	DUP1 CALLDATALOAD PUSH1 0x20 MSTORE       ; m[0x20] = n
+//	PUSH1 0x20 ADD PUSH1 0x00 MSTORE          ; m[0x00] = start of the elements
+//	PUSH1 0x20 PUSH2 0x0100 MSTORE            ; the output starts at 0x100
+//	PUSH1 0x20 MLOAD PUSH2 0x0120 MSTORE
+//	PUSH1 0x20 MLOAD PUSH1 0x05 SHL PUSH2 0x0140 ADD PUSH1 0x60 MSTORE ; m[0x60] = end of the output
+//	loop:
+//	PUSH1 0x20 MLOAD PUSH1 0x40 MLOAD LT ISZERO PUSH2 done JUMPI ; while m[0x40] = i < n
+//	PUSH1 0x40 MLOAD PUSH1 0x05 SHL PUSH1 0x00 MLOAD ADD CALLDATALOAD PUSH1 0x00 MLOAD ADD PUSH1 0x80 MSTORE ; m[0x80] = calls[i]
+//	PUSH2 0x0140 PUSH1 0x60 MLOAD SUB PUSH1 0x40 MLOAD PUSH1 0x05 SHL PUSH2 0x0140 ADD MSTORE ; offset of results[i]
+//	PUSH1 0x80 MLOAD PUSH1 0x40 ADD CALLDATALOAD PUSH1 0x80 MLOAD ADD PUSH1 0xa0 MSTORE ; m[0xa0] = calls[i].callData
+//	PUSH1 0xa0 MLOAD CALLDATALOAD PUSH1 0xc0 MSTORE ; m[0xc0] = its length
+//	PUSH1 0xc0 MLOAD PUSH1 0xa0 MLOAD PUSH1 0x20 ADD PUSH1 0x60 MLOAD PUSH1 0x60 ADD CALLDATACOPY
+//	PUSH1 0x00 PUSH1 0x00 PUSH1 0xc0 MLOAD PUSH1 0x60 MLOAD PUSH1 0x60 ADD PUSH1 0x00 PUSH1 0x80 MLOAD CALLDATALOAD GAS CALL
+//	DUP1 PUSH2 ok JUMPI                       ; a failed call reverts everything
+//	PUSH1 0x80 MLOAD PUSH1 0x20 ADD CALLDATALOAD PUSH2 ok JUMPI ; unless calls[i].allowFailure
+//	RETURNDATASIZE PUSH1 0x00 PUSH1 0x00 RETURNDATACOPY RETURNDATASIZE PUSH1 0x00 REVERT
+//	ok:
+//	PUSH1 0x60 MLOAD MSTORE                   ; results[i].success
+//	PUSH1 0x40 PUSH1 0x60 MLOAD PUSH1 0x20 ADD MSTORE
+//	RETURNDATASIZE PUSH1 0x60 MLOAD PUSH1 0x40 ADD MSTORE
+//	RETURNDATASIZE PUSH1 0x00 PUSH1 0x60 MLOAD PUSH1 0x60 ADD RETURNDATACOPY ; results[i].returnData
+//	RETURNDATASIZE PUSH1 0x1f ADD PUSH1 0x1f NOT AND PUSH1 0x60 MLOAD ADD PUSH1 0x60 ADD PUSH1 0x60 MSTORE
+//	PUSH1 0x40 MLOAD PUSH1 0x01 ADD PUSH1 0x40 MSTORE
+//	PUSH2 loop JUMP
+//	done:
+//	PUSH2 0x0100 PUSH1 0x60 MLOAD SUB PUSH2 0x0100 RETURN
+var MulticallCode = common.FromHex("0x60043560040180356020526020016000526020610100526020516101205260205160051b610140016060525b60205160405110156100ef5760405160051b6000510135600051016080526101406060510360405160051b6101400152608051604001356080510160a05260a0513560c05260c05160a051602001606051606001376000600060c0516060516060016000608051355af1806100b057608051602001356100b0573d600060003e3d6000fd5b606051526040606051602001523d606051604001523d60006060516060013e3d601f01601f19166060510160600160605260405160010160405261002b565b61010060605103610100f3")
@@ -0,0 +1,114 @@
+package ticker
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var accessListGasSavedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Name:      "access_list_gas_saved_total",
+	},
+	[]string{"chain"},
+)
+
+// accessListResult is the response to eth_createAccessList.
+type accessListResult struct {
+	AccessList ethtypes.AccessList `json:"accessList"`
+	GasUsed    hexutil.Uint64      `json:"gasUsed"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// createAccessList asks the node for the storage the call touches, as of the
+// pending block.
+func (w *Watcher) createAccessList(ctx context.Context, msg ethereum.CallMsg) (*accessListResult, error) {
+	arg := map[string]any{
+		"from": msg.From,
+		"data": hexutil.Bytes(msg.Data),
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+
+	var res accessListResult
+	if err := w.rpc.CallContext(ctx, &res, "eth_createAccessList", arg, "pending"); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// withAccessList tries an access list for the call. If the node can make one
+// and it lowers the estimate, it's set on msg and the new estimate is returned.
+// Otherwise, msg is left alone and the old estimate is returned. Failures here
+// only cost us the savings, so they're logged and not returned.
+func (w *Watcher) withAccessList(ctx context.Context, msg *ethereum.CallMsg, estimate uint64) uint64 {
+	logger := w.logger.With().Int("walletIndex", w.walletIndex).Logger()
+
+	res, err := w.createAccessList(ctx, *msg)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to create access list.")
+		return estimate
+	}
+
+	if res.Error != "" || len(res.AccessList) == 0 {
+		return estimate
+	}
+
+	withList := *msg
+	withList.AccessList = res.AccessList
+
+	listEstimate, err := w.client.EstimateGas(ctx, withList)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to estimate gas usage with access list.")
+		return estimate
+	}
+
+	if listEstimate >= estimate {
+		return estimate
+	}
+
+	logger.Info().Msgf("Access list for %d addresses lowers the estimate from %d to %d.", len(res.AccessList), estimate, listEstimate)
+	accessListGasSavedTotal.With(prometheus.Labels{"chain": w.chainID.String()}).Add(float64(estimate - listEstimate))
+
+	msg.AccessList = res.AccessList
+	return listEstimate
+}
+
+// newTx builds the transaction for the call. It's an EIP-2930 transaction if
+// there's an access list, and a legacy one otherwise.
+func (w *Watcher) newTx(nonce uint64, gasPrice *big.Int, gasLimit uint64, msg ethereum.CallMsg) *ethtypes.Transaction {
+	if len(msg.AccessList) != 0 {
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    w.chainID,
+			Nonce:      nonce,
+			GasPrice:   gasPrice,
+			Gas:        gasLimit,
+			To:         msg.To,
+			Value:      msg.Value,
+			Data:       msg.Data,
+			AccessList: msg.AccessList,
+		})
+	}
+
+	return ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gasLimit,
+		To:       msg.To,
+		Value:    msg.Value,
+		Data:     msg.Data,
+	})
+}
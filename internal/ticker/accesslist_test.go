@@ -0,0 +1,107 @@
+package ticker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTx(t *testing.T) {
+	w := &Watcher{chainID: big.NewInt(137)}
+	to := common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1")
+
+	msg := ethereum.CallMsg{To: &to, Data: common.FromHex("0xa9059cbb")}
+
+	tx := w.newTx(3, big.NewInt(100), 50_000, msg)
+	assert.EqualValues(t, ethtypes.LegacyTxType, tx.Type())
+
+	msg.AccessList = ethtypes.AccessList{{Address: to, StorageKeys: []common.Hash{{}}}}
+
+	tx = w.newTx(3, big.NewInt(100), 50_000, msg)
+	assert.EqualValues(t, ethtypes.AccessListTxType, tx.Type())
+	assert.Equal(t, msg.AccessList, tx.AccessList())
+	assert.Equal(t, big.NewInt(137), tx.ChainId())
+	assert.Equal(t, big.NewInt(100), tx.GasPrice())
+}
+
+// accessListNode answers eth_createAccessList with a canned result, or fails.
+type accessListNode struct {
+	res *accessListResult
+	err error
+}
+
+func (n *accessListNode) CallContext(_ context.Context, result any, _ string, _ ...any) error {
+	if n.err != nil {
+		return n.err
+	}
+	b, err := json.Marshal(n.res)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+// fixedEstimate estimates the same gas for any call that has an access list.
+type fixedEstimate struct {
+	EthClient
+	gas uint64
+}
+
+func (c *fixedEstimate) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if len(msg.AccessList) == 0 {
+		return 0, errors.New("expected an access list")
+	}
+	return c.gas, nil
+}
+
+func TestWithAccessList(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	to := common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1")
+	list := ethtypes.AccessList{{Address: to, StorageKeys: []common.Hash{{}}}}
+
+	for _, tc := range []struct {
+		name     string
+		node     *accessListNode
+		gas      uint64
+		expected uint64
+		withList bool
+	}{
+		{name: "smaller", node: &accessListNode{res: &accessListResult{AccessList: list}}, gas: 45_000, expected: 45_000, withList: true},
+		{name: "not smaller", node: &accessListNode{res: &accessListResult{AccessList: list}}, gas: 50_000, expected: 50_000},
+		{name: "empty", node: &accessListNode{res: &accessListResult{}}, gas: 45_000, expected: 50_000},
+		{name: "call error", node: &accessListNode{res: &accessListResult{AccessList: list, Error: "execution reverted"}}, gas: 45_000, expected: 50_000},
+		{name: "unsupported", node: &accessListNode{err: errors.New("the method eth_createAccessList does not exist/is not available")}, gas: 45_000, expected: 50_000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &Watcher{
+				logger:  &logger,
+				chainID: big.NewInt(137),
+				rpc:     tc.node,
+				client:  &fixedEstimate{gas: tc.gas},
+			}
+
+			msg := ethereum.CallMsg{To: &to, Data: common.FromHex("0xa9059cbb")}
+
+			gas := w.withAccessList(ctx, &msg, 50_000)
+			assert.Equal(t, tc.expected, gas)
+
+			tx := w.newTx(3, big.NewInt(100), gas, msg)
+			if tc.withList {
+				assert.EqualValues(t, ethtypes.AccessListTxType, tx.Type())
+				assert.Equal(t, list, tx.AccessList())
+			} else {
+				assert.EqualValues(t, ethtypes.LegacyTxType, tx.Type())
+				assert.Empty(t, msg.AccessList)
+			}
+		})
+	}
+}
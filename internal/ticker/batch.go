@@ -0,0 +1,339 @@
+package ticker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/multicall"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/trace"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// BatchConfig controls the batching of requests through Multicall3.
+//
+// Batched calls come from the Multicall3 contract rather than from one of our
+// wallets, so only contracts that don't care about msg.sender should be listed.
+type BatchConfig struct {
+	// Multicall is the address of the Multicall3 contract.
+	Multicall common.Address
+	// Contracts are the contracts whose requests can be batched.
+	Contracts map[common.Address]struct{}
+	// MaxSize is the largest number of requests in one transaction.
+	MaxSize int
+}
+
+// NewBatchConfig builds the batching configuration from its settings.
+// contracts is a comma-separated list of addresses. If it's empty, or maxSize
+// is less than 2, batching is off and the result is nil. An empty multicall
+// means the usual Multicall3 address.
+func NewBatchConfig(multicallAddr string, contracts string, maxSize int) (*BatchConfig, error) {
+	c := &BatchConfig{
+		Multicall: multicall.Address,
+		Contracts: make(map[common.Address]struct{}),
+		MaxSize:   maxSize,
+	}
+
+	if multicallAddr != "" {
+		if !common.IsHexAddress(multicallAddr) {
+			return nil, fmt.Errorf("invalid Multicall3 address %q", multicallAddr)
+		}
+		c.Multicall = common.HexToAddress(multicallAddr)
+	}
+
+	for _, s := range strings.Split(contracts, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid batch contract address %q", s)
+		}
+		c.Contracts[common.HexToAddress(s)] = struct{}{}
+	}
+
+	if len(c.Contracts) == 0 || maxSize < 2 {
+		return nil, nil
+	}
+
+	return c, nil
+}
+
+// batchable says whether the request can go in a batch. Requests that send
+// value, set their own gas limit or have an expectation to simulate always go
+// alone, and user operations are bundled separately.
+func (c *BatchConfig) batchable(req *models.MetaTransactionRequest) bool {
+	if c == nil || !req.To.Valid || !req.Value.IsZero() || !req.GasLimit.IsZero() || req.UserOpHash.Valid || req.Expectation.Valid {
+		return false
+	}
+	_, ok := c.Contracts[common.BytesToAddress(req.To.Bytes)]
+	return ok
+}
+
+// batchFor returns the requests to send in the same transaction as first,
+// which comes first in the result. The others are chosen from the waiting
+// requests that match ready.
+func (w *Watcher) batchFor(ctx context.Context, first *models.MetaTransactionRequest, ready []qm.QueryMod) ([]*models.MetaTransactionRequest, error) {
+	if first.UserOpHash.Valid {
+		return w.bundleFor(ctx, first, ready)
+	}
+
+	batch := []*models.MetaTransactionRequest{first}
+
+	if !w.batching.batchable(first) {
+		return batch, nil
+	}
+
+	contracts := make([][]byte, 0, len(w.batching.Contracts))
+	for addr := range w.batching.Contracts {
+		contracts = append(contracts, addr.Bytes())
+	}
+
+	mods := append(ready,
+		models.MetaTransactionRequestWhere.ID.NEQ(first.ID),
+		qm.WhereIn(fmt.Sprintf("%q IN ?", cols.To), toInterfaces(contracts)...),
+		models.MetaTransactionRequestWhere.Value.IsNull(),
+		models.MetaTransactionRequestWhere.GasLimit.IsNull(),
+		models.MetaTransactionRequestWhere.UserOpHash.IsNull(),
+		models.MetaTransactionRequestWhere.Expectation.IsNull(),
+		qm.Limit(w.batching.MaxSize-1),
+	)
+
+	rest, err := models.MetaTransactionRequests(mods...).All(ctx, w.dbs.DBS().Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load requests for batch: %w", err)
+	}
+
+	return append(batch, rest...), nil
+}
+
+func toInterfaces[A any](as []A) []any {
+	out := make([]any, len(as))
+	for i, a := range as {
+		out[i] = a
+	}
+	return out
+}
+
+// callMsg builds the call for the requests. A single request is sent as it
+// is, and several are wrapped in an aggregate3 call with every call allowed to
+// fail. User operations always go through handleOps.
+func (w *Watcher) callMsg(reqs []*models.MetaTransactionRequest, gasPrice *big.Int) (ethereum.CallMsg, error) {
+	if reqs[0].UserOpHash.Valid {
+		return w.bundleCallMsg(reqs, gasPrice)
+	}
+
+	if len(reqs) == 1 {
+		return ethereum.CallMsg{
+			From:     w.sender.Address(),
+			To:       requestTo(reqs[0]),
+			GasPrice: gasPrice,
+			Value:    requestValue(reqs[0]),
+			Data:     reqs[0].Data,
+		}, nil
+	}
+
+	if w.batching == nil {
+		return ethereum.CallMsg{}, fmt.Errorf("found a batch of %d requests, but batching is off", len(reqs))
+	}
+
+	calls := make([]multicall.Call, len(reqs))
+	for i, req := range reqs {
+		calls[i] = multicall.Call{
+			Target:       common.BytesToAddress(req.To.Bytes),
+			AllowFailure: true,
+			CallData:     req.Data,
+		}
+	}
+
+	data, err := multicall.PackAggregate3(calls)
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("failed to encode batch: %w", err)
+	}
+
+	return ethereum.CallMsg{
+		From:     w.sender.Address(),
+		To:       &w.batching.Multicall,
+		GasPrice: gasPrice,
+		Data:     data,
+	}, nil
+}
+
+// batchAttempts is how many ticks in a row we try to work out the outcomes of
+// the calls in a mined batch before giving up on them.
+const batchAttempts = 10
+
+// confirmBatch reports the outcome of each request in a confirmed batch and
+// deletes them. The receipt doesn't say which calls succeeded, so that comes
+// from batchOutcomes. If that keeps failing, every request in the batch fails
+// with ReasonBatch rather than holding up the wallet.
+func (w *Watcher) confirmBatch(ctx context.Context, reqs []*models.MetaTransactionRequest, rec *ethtypes.Receipt, receipt *status.Receipt) error {
+	hash := common.BytesToHash(reqs[0].Hash.Bytes)
+	logger := w.logger.With().Int("walletIndex", w.walletIndex).Str("hash", hash.Hex()).Logger()
+
+	var results []multicall.Result
+	var logs [][]*status.Log
+	var revertData []byte
+
+	if rec.Status != ethtypes.ReceiptStatusSuccessful {
+		revertData = w.revertData(ctx, &logger, rec)
+	} else {
+		var err error
+		results, logs, err = w.batchOutcomes(ctx, &logger, len(reqs), rec)
+		if err != nil {
+			if w.batchFailures == nil {
+				w.batchFailures = make(map[common.Hash]int)
+			}
+			w.batchFailures[hash]++
+			if w.batchFailures[hash] < batchAttempts {
+				return err
+			}
+			logger.Error().Err(err).Msgf("Giving up on the outcomes of the batch after %d attempts.", batchAttempts)
+		}
+	}
+
+	// Split the fee evenly for the purpose of quotas. The first request picks
+	// up the remainder.
+	share, rem := new(big.Int).QuoRem(receipt.Fee, big.NewInt(int64(len(reqs))), new(big.Int))
+
+	err := w.inTx(ctx, func(dbTx *sql.Tx) error {
+		for i, req := range reqs {
+			var err error
+
+			switch {
+			case rec.Status != ethtypes.ReceiptStatusSuccessful:
+				// The whole transaction reverted.
+				err = w.prod.Confirmed(ctx, dbTx, &status.ConfirmedMsg{
+					ID:           req.ID,
+					Hash:         hash,
+					Successful:   false,
+					Receipt:      receipt,
+					RevertData:   revertData,
+					RevertReason: revertReason(revertData),
+				})
+			case results == nil:
+				err = w.prod.Failed(ctx, dbTx, &status.FailedMsg{
+					ID:      req.ID,
+					Reason:  status.ReasonBatch,
+					Message: "Couldn't work out the outcome of the request's call in its batch.",
+					Hash:    hash,
+					Receipt: receipt,
+				})
+			case results[i].Success:
+				err = w.prod.Confirmed(ctx, dbTx, &status.ConfirmedMsg{
+					ID:         req.ID,
+					Hash:       hash,
+					Successful: true,
+					Logs:       logs[i],
+					Receipt:    receipt,
+				})
+			default:
+				err = w.prod.Failed(ctx, dbTx, &status.FailedMsg{
+					ID:      req.ID,
+					Data:    results[i].ReturnData,
+					Hash:    hash,
+					Receipt: receipt,
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			fee := share
+			if i == 0 {
+				fee = new(big.Int).Add(share, rem)
+			}
+
+			if err := w.quotas.RecordSpend(ctx, dbTx, req.ClientID, fee, receipt.BlockTimestamp); err != nil {
+				return err
+			}
+
+			if _, err := req.Delete(ctx, dbTx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	delete(w.batchFailures, hash)
+	return nil
+}
+
+// batchOutcomes works out which calls in a successful batch transaction
+// succeeded, and the logs of each. It traces the transaction if the node
+// allows it. Otherwise, it replays the aggregate3 call with eth_call on top of
+// the parent block, which has the same caveats as the replay in revertData.
+// The replay doesn't give logs, so the receipt's logs go to the one call that
+// succeeded, if there's only one. There's no telling how to split them
+// between several.
+func (w *Watcher) batchOutcomes(ctx context.Context, logger *zerolog.Logger, size int, rec *ethtypes.Receipt) ([]multicall.Result, [][]*status.Log, error) {
+	logs := make([][]*status.Log, size)
+
+	frame, err := trace.Transaction(ctx, w.rpc, rec.TxHash)
+	if err == nil {
+		results, err := multicall.UnpackAggregate3(frame.Output)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode batch results: %w", err)
+		}
+
+		if len(results) != size || len(frame.Calls) != size {
+			return nil, nil, fmt.Errorf("batch has %d requests, but the trace has %d results and %d calls", size, len(results), len(frame.Calls))
+		}
+
+		for i, r := range results {
+			if !r.Success {
+				continue
+			}
+			for _, l := range frame.Calls[i].AllLogs() {
+				logs[i] = append(logs[i], &status.Log{Address: l.Address, Topics: l.Topics, Data: l.Data})
+			}
+		}
+
+		return results, logs, nil
+	}
+
+	logger.Debug().Err(err).Msg("Couldn't trace batch transaction, replaying it instead.")
+
+	out, err := w.callAtParent(ctx, rec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to replay batch transaction: %w", err)
+	}
+
+	results, err := multicall.UnpackAggregate3(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode batch results: %w", err)
+	}
+
+	if len(results) != size {
+		return nil, nil, fmt.Errorf("batch has %d requests, but the replay has %d results", size, len(results))
+	}
+
+	var succeeded []int
+	for i, r := range results {
+		if r.Success {
+			succeeded = append(succeeded, i)
+		}
+	}
+
+	switch {
+	case len(succeeded) == 1:
+		for _, l := range rec.Logs {
+			logs[succeeded[0]] = append(logs[succeeded[0]], &status.Log{Address: l.Address, Topics: l.Topics, Data: l.Data})
+		}
+	case len(rec.Logs) != 0:
+		logger.Warn().Msg("Couldn't split the logs of the batch between its calls, leaving them out.")
+	}
+
+	return results, logs, nil
+}
@@ -0,0 +1,155 @@
+package ticker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/entrypoint"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// bundleFor returns the user operations to send in the same handleOps call as
+// first, which comes first in the result. The others are waiting operations
+// for the same EntryPoint.
+func (w *Watcher) bundleFor(ctx context.Context, first *models.MetaTransactionRequest, ready []qm.QueryMod) ([]*models.MetaTransactionRequest, error) {
+	bundle := []*models.MetaTransactionRequest{first}
+
+	if w.bundleMaxSize < 2 {
+		return bundle, nil
+	}
+
+	mods := append(ready,
+		models.MetaTransactionRequestWhere.ID.NEQ(first.ID),
+		models.MetaTransactionRequestWhere.To.EQ(first.To),
+		models.MetaTransactionRequestWhere.UserOpHash.IsNotNull(),
+		qm.Limit(w.bundleMaxSize-1),
+	)
+
+	rest, err := models.MetaTransactionRequests(mods...).All(ctx, w.dbs.DBS().Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user operations for bundle: %w", err)
+	}
+
+	return append(bundle, rest...), nil
+}
+
+// bundleCallMsg builds the handleOps call for the user operations. We collect
+// the fees as the beneficiary.
+func (w *Watcher) bundleCallMsg(reqs []*models.MetaTransactionRequest, gasPrice *big.Int) (ethereum.CallMsg, error) {
+	ops := make([]*entrypoint.UserOperation, len(reqs))
+	for i, req := range reqs {
+		op, err := entrypoint.Decode(req.Data)
+		if err != nil {
+			return ethereum.CallMsg{}, fmt.Errorf("failed to decode user operation for request %s: %w", req.ID, err)
+		}
+		ops[i] = op
+	}
+
+	data, err := entrypoint.HandleOpsData(ops, w.sender.Address())
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("failed to encode bundle: %w", err)
+	}
+
+	return ethereum.CallMsg{
+		From:     w.sender.Address(),
+		To:       requestTo(reqs[0]),
+		GasPrice: gasPrice,
+		Data:     data,
+	}, nil
+}
+
+// confirmUserOps reports the outcome of each user operation in a confirmed
+// handleOps transaction and deletes them. The outcomes come from the
+// EntryPoint's UserOperationEvent logs. If the whole transaction reverted, they
+// all get its revert data.
+func (w *Watcher) confirmUserOps(ctx context.Context, reqs []*models.MetaTransactionRequest, rec *ethtypes.Receipt, receipt *status.Receipt) error {
+	hash := common.BytesToHash(reqs[0].Hash.Bytes)
+	logger := w.logger.With().Int("walletIndex", w.walletIndex).Str("hash", hash.Hex()).Logger()
+
+	var outcomes map[common.Hash]*entrypoint.Outcome
+	var revertData []byte
+
+	if rec.Status == ethtypes.ReceiptStatusSuccessful {
+		outcomes = entrypoint.Outcomes(common.BytesToAddress(reqs[0].To.Bytes), rec.Logs)
+	} else {
+		revertData = w.revertData(ctx, &logger, rec)
+	}
+
+	share, rem := new(big.Int).QuoRem(receipt.Fee, big.NewInt(int64(len(reqs))), new(big.Int))
+
+	return w.inTx(ctx, func(dbTx *sql.Tx) error {
+		for i, req := range reqs {
+			o, ok := outcomes[common.BytesToHash(req.UserOpHash.Bytes)]
+
+			switch {
+			case outcomes == nil:
+				// The whole transaction reverted.
+				err := w.prod.Confirmed(ctx, dbTx, &status.ConfirmedMsg{
+					ID:           req.ID,
+					Hash:         hash,
+					Successful:   false,
+					Receipt:      receipt,
+					RevertData:   revertData,
+					RevertReason: revertReason(revertData),
+				})
+				if err != nil {
+					return err
+				}
+			case !ok:
+				// Shouldn't happen: handleOps reverts if any operation fails
+				// validation.
+				err := w.prod.Failed(ctx, dbTx, &status.FailedMsg{
+					ID:      req.ID,
+					Reason:  status.ReasonUserOperation,
+					Message: "No UserOperationEvent for the operation.",
+					Hash:    hash,
+					Receipt: receipt,
+				})
+				if err != nil {
+					return err
+				}
+			case o.Success:
+				logs := make([]*status.Log, len(o.Logs))
+				for i, l := range o.Logs {
+					logs[i] = &status.Log{Address: l.Address, Topics: l.Topics, Data: l.Data}
+				}
+
+				err := w.prod.Confirmed(ctx, dbTx, &status.ConfirmedMsg{
+					ID:         req.ID,
+					Hash:       hash,
+					Successful: true,
+					Logs:       logs,
+					Receipt:    receipt,
+				})
+				if err != nil {
+					return err
+				}
+			default:
+				if err := w.prod.Failed(ctx, dbTx, &status.FailedMsg{ID: req.ID, Data: o.RevertReason, Hash: hash, Receipt: receipt}); err != nil {
+					return err
+				}
+			}
+
+			fee := share
+			if i == 0 {
+				fee = new(big.Int).Add(share, rem)
+			}
+
+			if err := w.quotas.RecordSpend(ctx, dbTx, req.ClientID, fee, receipt.BlockTimestamp); err != nil {
+				return err
+			}
+
+			if _, err := req.Delete(ctx, dbTx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,115 @@
+package ticker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/gasprice"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+var delayedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Name:      "delayed_total",
+	},
+	[]string{"chain"},
+)
+
+// ceiling returns the highest gas price we may pay for all of the requests:
+// the lowest of our own maximum and theirs. It returns nil if there's no limit.
+func (w *Watcher) ceiling(reqs ...*models.MetaTransactionRequest) *big.Int {
+	limit := w.maxGasPrice
+	for _, req := range reqs {
+		if req.MaxGasPrice.IsZero() {
+			continue
+		}
+		if m := req.MaxGasPrice.Int(nil); limit == nil || m.Cmp(limit) < 0 {
+			limit = m
+		}
+	}
+	return limit
+}
+
+// delay marks the requests as waiting for the gas price to come down, and
+// sends a Delayed message for each one that wasn't already waiting. hash is
+// the transaction we've stopped boosting, if there is one.
+func (w *Watcher) delay(ctx context.Context, logger *zerolog.Logger, reqs []*models.MetaTransactionRequest, hash *common.Hash, gasPrice, limit *big.Int) error {
+	return w.inTx(ctx, func(dbTx *sql.Tx) error {
+		for _, req := range reqs {
+			if req.DelayedAt.Valid {
+				continue
+			}
+
+			logger.Warn().Str("requestId", req.ID).Msgf("Gas price %d is above the maximum of %d, holding the request.", gasPrice, limit)
+
+			req.DelayedAt = null.TimeFrom(time.Now())
+			if _, err := req.Update(ctx, dbTx, boil.Whitelist(cols.DelayedAt, cols.UpdatedAt)); err != nil {
+				return err
+			}
+
+			err := w.prod.Delayed(ctx, dbTx, &status.DelayedMsg{
+				ID:          req.ID,
+				Hash:        hash,
+				GasPrice:    gasPrice,
+				MaxGasPrice: limit,
+			})
+			if err != nil {
+				return err
+			}
+
+			delayedTotal.With(prometheus.Labels{"chain": w.chainID.String()}).Inc()
+		}
+		return nil
+	})
+}
+
+// release puts delayed requests that haven't been submitted back in the queue
+// if the gas price for them has come down far enough.
+func (w *Watcher) release(ctx context.Context) error {
+	delayed, err := models.MetaTransactionRequests(
+		w.onChain(),
+		models.MetaTransactionRequestWhere.WalletIndex.EQ(w.walletIndex),
+		models.MetaTransactionRequestWhere.SubmittedBlockNumber.IsNull(),
+		models.MetaTransactionRequestWhere.DelayedAt.IsNotNull(),
+	).All(ctx, w.dbs.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	prices := make(map[gasprice.Pricer]*big.Int)
+
+	for _, req := range delayed {
+		pricer := w.gasPrices.For(req.Priority)
+
+		price, ok := prices[pricer]
+		if !ok {
+			price, err = pricer.GasPrice(ctx)
+			if err != nil {
+				return err
+			}
+			prices[pricer] = price
+		}
+
+		if limit := w.ceiling(req); limit != nil && price.Cmp(limit) > 0 {
+			continue
+		}
+
+		req.DelayedAt = null.Time{}
+		if _, err := req.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(cols.DelayedAt, cols.UpdatedAt)); err != nil {
+			return fmt.Errorf("failed to release request %s: %w", req.ID, err)
+		}
+	}
+
+	return nil
+}
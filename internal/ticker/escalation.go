@@ -0,0 +1,219 @@
+package ticker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// minReplacementPercent is how much a replacement has to raise the gas price
+// for nodes to accept it.
+const minReplacementPercent = 10
+
+// cancelPercent is how much a cancellation raises the gas price.
+const cancelPercent = 20
+
+// Step is one step of an escalation schedule.
+type Step struct {
+	// AfterBlocks is the number of blocks since the transaction was first
+	// submitted.
+	AfterBlocks int64
+	// Percent raises the gas price by this much.
+	Percent int64
+	// Cap, if set, is a gas price in wei that the step moves to, and that later
+	// steps won't go above.
+	Cap *big.Int
+	// Cancel replaces the transaction with an empty one, to give up on the
+	// request.
+	Cancel bool
+}
+
+// Escalation is a schedule of boosts for a transaction that isn't getting
+// mined. The watcher takes the steps in order, one at a time.
+type Escalation []Step
+
+// ParseEscalation parses a comma-separated list of steps of the form
+// blocks:action, where action is +percent%, cap=wei or cancel. For example,
+//
+//	10:+15%,30:+50%,60:cap=50000000000,200:cancel
+//
+// Steps have to be in order of blocks, and a cancel has to come last. An empty
+// string gives a nil schedule.
+func ParseEscalation(s string) (Escalation, error) {
+	var out Escalation
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		rawBlocks, action, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid escalation step %q, should look like blocks:action", entry)
+		}
+
+		blocks, err := strconv.ParseInt(rawBlocks, 10, 64)
+		if err != nil || blocks <= 0 {
+			return nil, fmt.Errorf("invalid block count %q in escalation step %q", rawBlocks, entry)
+		}
+
+		if n := len(out); n != 0 {
+			if out[n-1].Cancel {
+				return nil, fmt.Errorf("escalation step %q comes after a cancel", entry)
+			}
+			if blocks <= out[n-1].AfterBlocks {
+				return nil, fmt.Errorf("escalation step %q isn't after the step before it", entry)
+			}
+		}
+
+		step := Step{AfterBlocks: blocks}
+
+		switch {
+		case action == "cancel":
+			step.Cancel = true
+		case strings.HasPrefix(action, "cap="):
+			c, ok := new(big.Int).SetString(strings.TrimPrefix(action, "cap="), 10)
+			if !ok || c.Sign() <= 0 {
+				return nil, fmt.Errorf("invalid cap in escalation step %q", entry)
+			}
+			step.Cap = c
+		case strings.HasPrefix(action, "+") && strings.HasSuffix(action, "%"):
+			p, err := strconv.ParseInt(action[1:len(action)-1], 10, 64)
+			if err != nil || p < minReplacementPercent {
+				return nil, fmt.Errorf("invalid increase in escalation step %q, must be at least %d%%", entry, minReplacementPercent)
+			}
+			step.Percent = p
+		default:
+			return nil, fmt.Errorf("unknown action in escalation step %q", entry)
+		}
+
+		out = append(out, step)
+	}
+
+	return out, nil
+}
+
+// due returns the step to take now, given the number of steps already taken
+// and the number of blocks since submission. It returns -1 if there isn't one.
+func (e Escalation) due(taken int, age *big.Int) int {
+	if taken >= len(e) || age.Cmp(big.NewInt(e[taken].AfterBlocks)) < 0 {
+		return -1
+	}
+	return taken
+}
+
+// price returns the gas price for step i, replacing a transaction priced at
+// old. The second result is false if the step can't raise the price enough to
+// replace the transaction, because of an earlier cap.
+func (e Escalation) price(i int, old *big.Int) (*big.Int, bool) {
+	minimum := new(big.Int).Mul(old, big.NewInt(100+minReplacementPercent))
+	minimum.Div(minimum, big.NewInt(100))
+
+	var price *big.Int
+	limit := e.limit(i)
+
+	if s := e[i]; s.Cap != nil {
+		price, limit = s.Cap, s.Cap
+	} else {
+		pct := s.Percent
+		if s.Cancel {
+			pct = cancelPercent
+		}
+		price = new(big.Int).Mul(old, big.NewInt(100+pct))
+		price.Div(price, big.NewInt(100))
+	}
+
+	// A cancel has to get mined to free up the nonce, so caps don't hold it back.
+	if limit != nil && !e[i].Cancel && price.Cmp(limit) > 0 {
+		price = limit
+	}
+
+	return price, price.Cmp(minimum) >= 0
+}
+
+// after returns the gas price for a boost once the schedule has run out, or
+// the transaction was canceled, replacing a transaction priced at old. This
+// is the current price, or the minimum for a replacement if that's higher.
+// The last cap in the schedule still holds, except for a cancel. The second
+// result is false if the cap leaves no room to replace the transaction.
+func (e Escalation) after(current, old *big.Int, cancel bool) (*big.Int, bool) {
+	minimum := new(big.Int).Mul(old, big.NewInt(100+minReplacementPercent))
+	minimum.Div(minimum, big.NewInt(100))
+
+	price := current
+	if price.Cmp(minimum) < 0 {
+		price = minimum
+	}
+
+	if limit := e.limit(len(e)); limit != nil && !cancel && price.Cmp(limit) > 0 {
+		price = limit
+	}
+
+	return price, price.Cmp(minimum) >= 0
+}
+
+// limit returns the cap set by the steps before step i, if any.
+func (e Escalation) limit(i int) *big.Int {
+	var limit *big.Int
+	for _, s := range e[:i] {
+		if s.Cap != nil {
+			limit = s.Cap
+		}
+	}
+	return limit
+}
+
+// skipStep moves the requests past their current escalation step without
+// sending anything.
+func (w *Watcher) skipStep(ctx context.Context, reqs []*models.MetaTransactionRequest) error {
+	return w.inTx(ctx, func(dbTx *sql.Tx) error {
+		for _, req := range reqs {
+			req.BoostStep++
+			if _, err := req.Update(ctx, dbTx, boil.Whitelist(cols.BoostStep, cols.UpdatedAt)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// confirmCanceled fails the requests in a confirmed cancellation. The fee is
+// split between them, as in a batch.
+func (w *Watcher) confirmCanceled(ctx context.Context, reqs []*models.MetaTransactionRequest, receipt *status.Receipt) error {
+	share, rem := new(big.Int).QuoRem(receipt.Fee, big.NewInt(int64(len(reqs))), new(big.Int))
+
+	return w.inTx(ctx, func(dbTx *sql.Tx) error {
+		for i, req := range reqs {
+			err := w.prod.Failed(ctx, dbTx, &status.FailedMsg{
+				ID:      req.ID,
+				Reason:  status.ReasonCanceled,
+				Message: fmt.Sprintf("Canceled after %d escalation steps.", req.BoostStep),
+			})
+			if err != nil {
+				return err
+			}
+
+			fee := share
+			if i == 0 {
+				fee = new(big.Int).Add(share, rem)
+			}
+
+			if err := w.quotas.RecordSpend(ctx, dbTx, req.ClientID, fee, receipt.BlockTimestamp); err != nil {
+				return err
+			}
+
+			if _, err := req.Delete(ctx, dbTx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,97 @@
+package ticker
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEscalation(t *testing.T) {
+	e, err := ParseEscalation("10:+15%, 30:+50%,60:cap=50000000000,200:cancel")
+	require.NoError(t, err)
+
+	assert.Equal(t, Escalation{
+		{AfterBlocks: 10, Percent: 15},
+		{AfterBlocks: 30, Percent: 50},
+		{AfterBlocks: 60, Cap: big.NewInt(50_000_000_000)},
+		{AfterBlocks: 200, Cancel: true},
+	}, e)
+
+	e, err = ParseEscalation("")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	for _, s := range []string{
+		"10",
+		"x:+15%",
+		"10:+5%",
+		"30:+15%,10:+15%",
+		"10:cancel,20:+15%",
+		"10:cap=-1",
+		"10:double",
+	} {
+		_, err := ParseEscalation(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestEscalationSteps(t *testing.T) {
+	e, err := ParseEscalation("10:+15%,30:cap=1000,60:+50%,200:cancel")
+	require.NoError(t, err)
+
+	assert.Equal(t, -1, e.due(0, big.NewInt(9)))
+	assert.Equal(t, 0, e.due(0, big.NewInt(10)))
+	assert.Equal(t, 1, e.due(1, big.NewInt(45)))
+	assert.Equal(t, -1, e.due(2, big.NewInt(45)))
+	assert.Equal(t, -1, e.due(4, big.NewInt(1000)))
+
+	price, ok := e.price(0, big.NewInt(100))
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(115), price)
+
+	price, ok = e.price(1, big.NewInt(500))
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(1000), price)
+
+	// Held at the cap from the step before, so it can't replace anything.
+	_, ok = e.price(2, big.NewInt(1000))
+	assert.False(t, ok)
+
+	price, ok = e.price(2, big.NewInt(800))
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(1000), price)
+
+	// Cancels ignore caps.
+	price, ok = e.price(3, big.NewInt(1000))
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(1200), price)
+}
+
+func TestEscalationAfter(t *testing.T) {
+	e, err := ParseEscalation("10:+15%,30:cap=1000")
+	require.NoError(t, err)
+
+	// At least the minimum for a replacement.
+	price, ok := e.after(big.NewInt(50), big.NewInt(500), false)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(550), price)
+
+	price, ok = e.after(big.NewInt(700), big.NewInt(500), false)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(700), price)
+
+	// The last cap still holds.
+	price, ok = e.after(big.NewInt(5000), big.NewInt(500), false)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(1000), price)
+
+	_, ok = e.after(big.NewInt(5000), big.NewInt(1000), false)
+	assert.False(t, ok)
+
+	// Except for a cancel.
+	price, ok = e.after(big.NewInt(50), big.NewInt(1000), true)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(1100), price)
+}
@@ -0,0 +1,73 @@
+package ticker
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+)
+
+// Block tags that can replace counting confirmations. A transaction is
+// confirmed once its block is at or below the tagged block.
+const (
+	FinalityFinalized = "finalized"
+	FinalitySafe      = "safe"
+)
+
+// ValidFinality says whether tag is a setting the watcher understands. Empty
+// means counting confirmations.
+func ValidFinality(tag string) bool {
+	return tag == "" || tag == FinalityFinalized || tag == FinalitySafe
+}
+
+// confirmed says whether the mined transaction for req, with receipt rec, is
+// confirmed. Without a finality tag, that means it has enough blocks on top of
+// it. With one, its block has to be covered by the tag. Either way, the
+// receipt's block has to be the one we reported as mined, and still be the
+// canonical block at its height.
+func (w *Watcher) confirmed(ctx context.Context, logger *zerolog.Logger, headNum *big.Int, req *models.MetaTransactionRequest, rec *ethtypes.Receipt) (bool, error) {
+	if minedHash := common.BytesToHash(req.MinedBlockHash.Bytes); rec.BlockHash != minedHash {
+		// The caller reports the move first.
+		return false, nil
+	}
+
+	if w.finality == "" {
+		conf := new(big.Int).Sub(headNum, rec.BlockNumber)
+		if conf.Cmp(w.confirmationBlocks) < 0 {
+			return false, nil
+		}
+	} else {
+		tag := big.NewInt(int64(rpc.FinalizedBlockNumber))
+		if w.finality == FinalitySafe {
+			tag = big.NewInt(int64(rpc.SafeBlockNumber))
+		}
+
+		final, err := w.client.HeaderByNumber(ctx, tag)
+		if err != nil {
+			return false, fmt.Errorf("failed to retrieve %s block: %w", w.finality, err)
+		}
+
+		if final.Number.Cmp(rec.BlockNumber) < 0 {
+			return false, nil
+		}
+	}
+
+	canon, err := w.canonicalHash(ctx, rec.BlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	if canon != rec.BlockHash {
+		// The receipt is stale. Wait for the node to catch up with itself, and
+		// we'll see the transaction move or disappear.
+		logger.Warn().Msgf("Receipt puts the transaction in block %s, but the canonical block %d is %s.", rec.BlockHash, rec.BlockNumber, canon)
+		return false, nil
+	}
+
+	return true, nil
+}
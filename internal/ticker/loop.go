@@ -3,13 +3,20 @@ package ticker
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/DIMO-Network/meta-transaction-processor/internal/gaslimit"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/gasprice"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/quota"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/sender"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/trace"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum"
@@ -17,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -31,11 +39,12 @@ import (
 // EthClient contains all the ethclient.Client methods that we use.
 type EthClient interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*ethtypes.Header, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
-	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
 	SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*ethtypes.Transaction, bool, error)
 }
 
 // ethJSONRPCError mirrors the methods of the unexported rpc.jsonError type from
@@ -48,69 +57,138 @@ type ethJSONRPCError interface {
 type Watcher struct {
 	logger             *zerolog.Logger
 	confirmationBlocks *big.Int
+	finality           string
 	boostAfterBlocks   *big.Int
+	gasPrices          *gasprice.Schedule
+	escalation         Escalation
+	gasLimits          *gaslimit.Policies
+	simulateAll        bool
+	accessLists        bool
+	maxGasPrice        *big.Int
 	prod               status.Producer
 	dbs                db.Store
 	client             EthClient
 	sender             sender.Sender
 	chainID            *big.Int
 	walletIndex        int
+	defaultChain       bool
 	disableBoosting    bool
+	quotas             *quota.Quotas
+	priorityAging      time.Duration
+	batching           *BatchConfig
+	bundleMaxSize      int
+	rpc                trace.Client
+	window             *chainWindow
+	// batchFailures counts the ticks on which we couldn't work out the
+	// outcomes of a mined batch, by transaction hash.
+	batchFailures map[common.Hash]int
 }
 
 func New(
 	logger *zerolog.Logger,
 	prod status.Producer,
 	confirmationBlocks *big.Int,
+	finality string,
 	boostAfterBlocks *big.Int,
+	gasPrices *gasprice.Schedule,
+	escalation Escalation,
+	gasLimits *gaslimit.Policies,
+	simulateAll bool,
+	accessLists bool,
+	maxGasPrice *big.Int,
 	dbs db.Store,
 	client *ethclient.Client,
 	chainID *big.Int,
 	sender sender.Sender,
 	walletIndex int,
+	defaultChain bool,
 	disableBoosting bool,
+	quotas *quota.Quotas,
+	priorityAging time.Duration,
+	batching *BatchConfig,
+	bundleMaxSize int,
 ) *Watcher {
 	return &Watcher{
 		logger:             logger,
 		confirmationBlocks: confirmationBlocks,
+		finality:           finality,
 		prod:               prod,
 		boostAfterBlocks:   boostAfterBlocks,
+		gasPrices:          gasPrices,
+		escalation:         escalation,
+		gasLimits:          gasLimits,
+		simulateAll:        simulateAll,
+		accessLists:        accessLists,
+		maxGasPrice:        maxGasPrice,
 		dbs:                dbs,
 		client:             client,
 		chainID:            chainID,
 		sender:             sender,
 		walletIndex:        walletIndex,
+		defaultChain:       defaultChain,
 		disableBoosting:    disableBoosting,
+		quotas:             quotas,
+		priorityAging:      priorityAging,
+		batching:           batching,
+		bundleMaxSize:      bundleMaxSize,
+		rpc:                client.Client(),
+		window:             newChainWindow(windowSize),
 	}
 }
 
 var cols = models.MetaTransactionRequestColumns
 
-var latestBlock = promauto.NewGauge(prometheus.GaugeOpts{
-	Namespace: "meta_transaction_processor",
-	Name:      "latest_block",
-})
+var latestBlock = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "meta_transaction_processor",
+		Name:      "latest_block",
+	},
+	[]string{"chain"},
+)
 
 var submittedTxBlockAge = promauto.NewGaugeVec(
 	prometheus.GaugeOpts{
 		Namespace: "meta_transaction_processor",
 		Name:      "submitted_tx_block_age",
 	},
-	[]string{"wallet"},
+	[]string{"chain", "wallet"},
+)
+
+var reorgsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Name:      "reorgs_total",
+	},
+	[]string{"chain"},
 )
 
+var expiredTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "meta_transaction_processor",
+	Name:      "expired_total",
+})
+
+// urgentDeadline is how close to its deadline a request has to be for us to
+// boost it more aggressively.
+const urgentDeadline = 5 * time.Minute
+
 func (w *Watcher) Tick(ctx context.Context) error {
-	// There's at most one submitted transaction per wallet.
-	if activeTx, err := models.MetaTransactionRequests(
+	// There's at most one submitted transaction per wallet, but it may carry a
+	// batch of requests. These all share the fields describing the transaction.
+	active, err := models.MetaTransactionRequests(
 		models.MetaTransactionRequestWhere.SubmittedBlockNumber.IsNotNull(),
+		w.onChain(),
 		models.MetaTransactionRequestWhere.WalletIndex.EQ(w.walletIndex),
-	).One(ctx, w.dbs.DBS().Reader); err != nil {
-		if err != sql.ErrNoRows {
-			return err
-		}
-		// If there's no submitted transaction, fall through to trying to submit something new.
-	} else {
+		qm.OrderBy(cols.BatchIndex+" ASC"),
+	).All(ctx, w.dbs.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	// If there's no submitted transaction, fall through to trying to submit something new.
+	if len(active) != 0 {
 		// We have a submitted but not confirmed (it would have been deleted) transaction.
+		activeTx := active[0]
+
 		subBlockNum, _ := activeTx.SubmittedBlockNumber.Float64()
 
 		head, err := w.client.HeaderByNumber(ctx, nil)
@@ -121,14 +199,24 @@ func (w *Watcher) Tick(ctx context.Context) error {
 		headNum := head.Number
 		headNumFloat, _ := headNum.Float64()
 
-		latestBlock.Set(headNumFloat)
+		latestBlock.With(prometheus.Labels{"chain": w.chainID.String()}).Set(headNumFloat)
 
 		logger := w.logger.With().Int64("block", headNum.Int64()).Int("walletIndex", w.walletIndex).Logger()
 
+		forked, err := w.window.update(ctx, w.client, head)
+		if err != nil {
+			return err
+		}
+
+		if forked != nil {
+			reorgsTotal.With(prometheus.Labels{"chain": w.chainID.String()}).Inc()
+			logger.Warn().Msgf("Chain reorganized from block %d.", forked)
+		}
+
 		// TODO(elffjs): Can we do this label-setting once?
-		submittedTxBlockAge.With(prometheus.Labels{"wallet": strconv.Itoa(w.walletIndex)}).Set(headNumFloat - subBlockNum)
+		submittedTxBlockAge.With(prometheus.Labels{"chain": w.chainID.String(), "wallet": strconv.Itoa(w.walletIndex)}).Set(headNumFloat - subBlockNum)
 
-		logger = logger.With().Str("requestId", activeTx.ID).Str("contract", common.BytesToAddress(activeTx.To).Hex()).Logger()
+		logger = logger.With().Str("requestId", activeTx.ID).Str("contract", contractLabel(activeTx)).Logger()
 
 		rec, err := w.client.TransactionReceipt(ctx, common.BytesToHash(activeTx.Hash.Bytes))
 		if err != nil {
@@ -139,9 +227,32 @@ func (w *Watcher) Tick(ctx context.Context) error {
 
 			if !activeTx.MinedBlockNumber.IsZero() {
 				logger.Info().Msg("Transaction no longer in the canonical chain.")
-				activeTx.MinedBlockNumber = types.NewNullDecimal(nil)
-				activeTx.MinedBlockHash = null.Bytes{}
-				_, err := activeTx.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(cols.MinedBlockNumber, cols.MinedBlockHash, cols.UpdatedAt))
+
+				oldBlockNumber := activeTx.MinedBlockNumber.Int(nil)
+				oldBlockHash := common.BytesToHash(activeTx.MinedBlockHash.Bytes)
+
+				err := w.inTx(ctx, func(dbTx *sql.Tx) error {
+					for _, req := range active {
+						req.MinedBlockNumber = types.NewNullDecimal(nil)
+						req.MinedBlockHash = null.Bytes{}
+
+						if _, err := req.Update(ctx, dbTx, boil.Whitelist(cols.MinedBlockNumber, cols.MinedBlockHash, cols.UpdatedAt)); err != nil {
+							return err
+						}
+
+						msg := &status.UnminedMsg{
+							ID:             req.ID,
+							Hash:           common.BytesToHash(req.Hash.Bytes),
+							OldBlockNumber: oldBlockNumber,
+							OldBlockHash:   oldBlockHash,
+						}
+
+						if err := w.prod.Unmined(ctx, dbTx, msg); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
 				if err != nil {
 					return err
 				}
@@ -152,38 +263,108 @@ func (w *Watcher) Tick(ctx context.Context) error {
 				lastSend = activeTx.BoostedBlockNumber.Int(nil)
 			}
 
-			if new(big.Int).Sub(headNum, lastSend).Cmp(w.boostAfterBlocks) >= 0 {
+			due := new(big.Int).Sub(headNum, lastSend).Cmp(w.boostAfterBlocks) >= 0
+
+			// With a schedule, the steps replace the usual boosting. Once
+			// they've run out, or the transaction was canceled, it's boosted
+			// every boostAfterBlocks, so that it can't get stuck.
+			step := -1
+			if w.escalation != nil {
+				if !activeTx.CanceledAt.Valid {
+					step = w.escalation.due(activeTx.BoostStep, new(big.Int).Sub(headNum, activeTx.SubmittedBlockNumber.Int(nil)))
+				}
+				due = step >= 0 || due && (activeTx.CanceledAt.Valid || activeTx.BoostStep >= len(w.escalation))
+			}
+
+			if due {
 				if w.disableBoosting {
 					logger.Warn().Msgf("Would have boosted after %d blocks, but boosting disabled.", new(big.Int).Sub(headNum, lastSend))
 					return nil
 				}
 				signer := ethtypes.LatestSignerForChainID(w.chainID)
 
-				gasPrice, err := w.client.SuggestGasPrice(ctx)
-				if err != nil {
-					return fmt.Errorf("failed to retrieve gas price estimate: %w", err)
-				}
+				oldGasPrice := activeTx.GasPrice.Int(nil)
 
-				gasPrice = new(big.Int).Mul(common.Big2, gasPrice)
+				var gasPrice, newBar *big.Int
+				cancel := false
 
-				oldGasPrice := activeTx.GasPrice.Int(nil)
+				if step >= 0 {
+					var ok bool
+					gasPrice, ok = w.escalation.price(step, oldGasPrice)
+					if !ok {
+						logger.Info().Msgf("Escalation step %d can't raise the gas price of %d enough to replace the transaction, skipping it.", step, oldGasPrice)
+						return w.skipStep(ctx, active)
+					}
+					newBar = new(big.Int).Mul(oldGasPrice, big.NewInt(100+minReplacementPercent))
+					newBar.Div(newBar, big.NewInt(100))
+					cancel = w.escalation[step].Cancel
+				} else if w.escalation != nil {
+					current, err := w.gasPrices.For(activeTx.Priority).GasPrice(ctx)
+					if err != nil {
+						return err
+					}
 
-				// Have to increase the old price by at least 10% to replace the transaction.
-				newBar := new(big.Int).Mul(oldGasPrice, big.NewInt(120))
-				newBar = new(big.Int).Div(newBar, big.NewInt(100))
+					cancel = activeTx.CanceledAt.Valid
 
-				if newBar.Cmp(gasPrice) > 0 {
-					gasPrice = newBar
+					var ok bool
+					gasPrice, ok = w.escalation.after(current, oldGasPrice, cancel)
+					if !ok {
+						logger.Debug().Msgf("The escalation cap doesn't leave room to replace the transaction with gas price %d.", oldGasPrice)
+						return nil
+					}
+					newBar = new(big.Int).Mul(oldGasPrice, big.NewInt(100+minReplacementPercent))
+					newBar.Div(newBar, big.NewInt(100))
+				} else {
+					gasPrice, err = w.gasPrices.For(activeTx.Priority).GasPrice(ctx)
+					if err != nil {
+						return err
+					}
+
+					bump := big.NewInt(120)
+
+					// If the request has a deadline coming up, there may not be time for
+					// another round of boosting. Signatures inside the calldata often
+					// stop working at the deadline, too.
+					if activeTx.ExpiresAt.Valid && time.Until(activeTx.ExpiresAt.Time) < urgentDeadline {
+						logger.Info().Msgf("Request expires at %s, boosting aggressively.", activeTx.ExpiresAt.Time)
+						gasPrice = new(big.Int).Mul(common.Big2, gasPrice)
+						bump = big.NewInt(150)
+					}
+
+					// Have to increase the old price by at least 10% to replace the transaction.
+					newBar = new(big.Int).Mul(oldGasPrice, bump)
+					newBar = new(big.Int).Div(newBar, big.NewInt(100))
+
+					if newBar.Cmp(gasPrice) > 0 {
+						gasPrice = newBar
+					}
+				}
+
+				// A cancellation doesn't run the requests, so their ceilings don't apply.
+				if limit := w.ceiling(active...); limit != nil && !cancel && gasPrice.Cmp(limit) > 0 {
+					if newBar.Cmp(limit) > 0 {
+						// Can't replace the transaction without going over.
+						hash := common.BytesToHash(activeTx.Hash.Bytes)
+						return w.delay(ctx, &logger, active, &hash, gasPrice, limit)
+					}
+					gasPrice = limit
 				}
 
-				callMsg := ethereum.CallMsg{
-					From:     w.sender.Address(),
-					To:       Ref(common.BytesToAddress(activeTx.To)),
-					GasPrice: gasPrice,
-					Data:     activeTx.Data,
+				callMsg, err := w.callMsg(active, gasPrice)
+				if err != nil {
+					return err
 				}
 
-				gasLimit, err := w.client.EstimateGas(ctx, callMsg)
+				var gasLimit uint64
+				if cancel {
+					// Send nothing to ourselves. Whichever of this and the
+					// original is mined, the nonce is used up.
+					self := w.sender.Address()
+					callMsg = ethereum.CallMsg{From: self, To: &self, GasPrice: gasPrice}
+					gasLimit = params.TxGas
+				} else {
+					gasLimit, err = w.gasLimit(ctx, activeTx, &callMsg)
+				}
 				if err != nil {
 					logger.Err(err).Msg("Failed to estimate gas usage for transaction.")
 
@@ -198,33 +379,30 @@ func (w *Watcher) Tick(ctx context.Context) error {
 								outData = data
 							}
 						}
-					} else {
+					} else if !errors.As(err, new(*gaslimit.OverCeiling)) {
 						return fmt.Errorf("error estimating gas: %w", err)
 					}
 
-					w.prod.Failed(&status.FailedMsg{ID: activeTx.ID, Data: outData})
+					estimateErr := err
 
-					_, err := activeTx.Delete(ctx, w.dbs.DBS().Writer)
-					if err != nil {
-						return fmt.Errorf("failed to delete un-estimateable transaction: %w", err)
-					}
+					return w.inTx(ctx, func(dbTx *sql.Tx) error {
+						for _, req := range active {
+							if err := w.prod.Failed(ctx, dbTx, failedEstimate(req.ID, outData, estimateErr)); err != nil {
+								return err
+							}
 
-					return nil
-				}
+							if _, err := req.Delete(ctx, dbTx); err != nil {
+								return fmt.Errorf("failed to delete un-estimateable transaction: %w", err)
+							}
+						}
 
-				gasLimit = 2 * gasLimit
+						return nil
+					})
+				}
 
 				nonce, _ := activeTx.Nonce.Uint64()
 
-				txd := &ethtypes.LegacyTx{
-					Nonce:    nonce,
-					GasPrice: gasPrice,
-					Gas:      gasLimit,
-					To:       callMsg.To,
-					Data:     callMsg.Data,
-				}
-
-				tx := ethtypes.NewTx(txd)
+				tx := w.newTx(nonce, gasPrice, gasLimit, callMsg)
 
 				sigHash := signer.Hash(tx)
 				sigBytes, err := w.sender.Sign(ctx, sigHash)
@@ -237,20 +415,57 @@ func (w *Watcher) Tick(ctx context.Context) error {
 					return fmt.Errorf("failed to attach signature to transaction: %w", err)
 				}
 
-				activeTx.BoostedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(headNum, 0))
-				activeTx.BoostedBlockHash = null.BytesFrom(signedTx.Hash().Bytes())
-				activeTx.Nonce = types.NewNullDecimal(new(decimal.Big).SetUint64(nonce))
-				activeTx.GasPrice = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(gasPrice, 0))
-				activeTx.Hash = null.BytesFrom(signedTx.Hash().Bytes())
+				oldHash := common.BytesToHash(activeTx.Hash.Bytes)
+
+				// Record the new hash before sending, so that we never lose track of
+				// a transaction that went out. If the send fails, we boost again.
+				err = w.inTx(ctx, func(dbTx *sql.Tx) error {
+					for _, req := range active {
+						req.BoostedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(headNum, 0))
+						req.BoostedBlockHash = null.BytesFrom(signedTx.Hash().Bytes())
+						req.Nonce = types.NewNullDecimal(new(decimal.Big).SetUint64(nonce))
+						req.GasPrice = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(gasPrice, 0))
+						req.Hash = null.BytesFrom(signedTx.Hash().Bytes())
+						req.DelayedAt = null.Time{}
+						if step >= 0 {
+							req.BoostStep = step + 1
+						}
+						if cancel && !req.CanceledAt.Valid {
+							req.CanceledAt = null.TimeFrom(time.Now())
+						}
+
+						if _, err := req.Update(ctx, dbTx, boil.Whitelist(cols.BoostedBlockHash, cols.BoostedBlockNumber, cols.Nonce, cols.GasPrice, cols.UpdatedAt, cols.Hash, cols.DelayedAt, cols.BoostStep, cols.CanceledAt)); err != nil {
+							return err
+						}
+
+						err := w.prod.Boosted(ctx, dbTx, &status.BoostedMsg{
+							ID:       req.ID,
+							OldHash:  oldHash,
+							NewHash:  signedTx.Hash(),
+							GasPrice: gasPrice,
+						})
+						if err != nil {
+							return err
+						}
+					}
 
-				_, err = activeTx.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(cols.BoostedBlockHash, cols.BoostedBlockNumber, cols.Nonce, cols.GasPrice, cols.UpdatedAt, cols.Hash))
+					return nil
+				})
 				if err != nil {
 					return err
 				}
 
-				logger.Info().Msgf("Boosting transaction with new gas price %d and hash %s.", gasPrice, signedTx.Hash())
+				if cancel {
+					logger.Info().Msgf("Canceling transaction with gas price %d and hash %s.", gasPrice, signedTx.Hash())
+				} else {
+					logger.Info().Msgf("Boosting transaction with new gas price %d and hash %s.", gasPrice, signedTx.Hash())
+				}
+
+				if err := w.client.SendTransaction(ctx, signedTx); err != nil {
+					return fmt.Errorf("failed to submit boosted transaction: %w", err)
+				}
 
-				return w.client.SendTransaction(ctx, signedTx)
+				return nil
 			} else {
 				return nil
 			}
@@ -260,45 +475,98 @@ func (w *Watcher) Tick(ctx context.Context) error {
 		if activeTx.MinedBlockNumber.IsZero() {
 			logger.Info().Msgf("Transaction mined in block %d.", rec.BlockNumber)
 
-			// We discount the possibility of sending mining and confirmation in the same tick.
-			w.prod.Mined(&status.MinedMsg{ID: activeTx.ID, Hash: common.BytesToHash(activeTx.Hash.Bytes)})
+			receipt, err := w.receiptDetails(ctx, rec)
+			if err != nil {
+				return err
+			}
+
+			return w.inTx(ctx, func(dbTx *sql.Tx) error {
+				for _, req := range active {
+					req.MinedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(rec.BlockNumber, 0))
+					req.MinedBlockHash = null.BytesFrom(rec.BlockHash.Bytes())
 
-			activeTx.MinedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(rec.BlockNumber, 0))
-			activeTx.MinedBlockHash = null.BytesFrom(rec.BlockHash.Bytes())
+					_, err := req.Update(ctx, dbTx, boil.Whitelist(
+						models.MetaTransactionRequestColumns.MinedBlockNumber,
+						models.MetaTransactionRequestColumns.MinedBlockHash,
+						models.MetaTransactionRequestColumns.UpdatedAt,
+					))
+					if err != nil {
+						return err
+					}
 
-			_, err := activeTx.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(
-				models.MetaTransactionRequestColumns.MinedBlockNumber,
-				models.MetaTransactionRequestColumns.MinedBlockHash,
-				models.MetaTransactionRequestColumns.UpdatedAt,
-			))
+					// We discount the possibility of sending mining and confirmation in the same tick.
+					if err := w.prod.Mined(ctx, dbTx, &status.MinedMsg{ID: req.ID, Hash: common.BytesToHash(req.Hash.Bytes), Receipt: receipt}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+
+		confirmed, err := w.confirmed(ctx, &logger, headNum, activeTx, rec)
+		if err != nil {
 			return err
 		}
 
-		conf := new(big.Int).Sub(headNum, rec.BlockNumber)
+		if confirmed {
+			receipt, err := w.receiptDetails(ctx, rec)
+			if err != nil {
+				return err
+			}
+
+			logger.Info().Msg("Transaction confirmed.")
 
-		if conf.Cmp(w.confirmationBlocks) >= 0 {
-			logs := make([]*status.Log, len(rec.Logs))
+			if activeTx.CanceledAt.Valid {
+				err = w.confirmCanceled(ctx, active, receipt)
+			} else if activeTx.UserOpHash.Valid {
+				err = w.confirmUserOps(ctx, active, rec, receipt)
+			} else if len(active) > 1 {
+				err = w.confirmBatch(ctx, active, rec, receipt)
+			} else {
+				logs := make([]*status.Log, len(rec.Logs))
 
-			for i, l := range rec.Logs {
-				logs[i] = &status.Log{
-					Address: l.Address,
-					Topics:  l.Topics,
-					Data:    l.Data,
+				for i, l := range rec.Logs {
+					logs[i] = &status.Log{
+						Address: l.Address,
+						Topics:  l.Topics,
+						Data:    l.Data,
+					}
 				}
-			}
 
-			msg := &status.ConfirmedMsg{
-				ID:         activeTx.ID,
-				Hash:       common.BytesToHash(activeTx.Hash.Bytes),
-				Successful: rec.Status == 1,
-				Logs:       logs,
-			}
+				msg := &status.ConfirmedMsg{
+					ID:         activeTx.ID,
+					Hash:       common.BytesToHash(activeTx.Hash.Bytes),
+					Successful: rec.Status == 1,
+					Logs:       logs,
+					Receipt:    receipt,
+				}
 
-			logger.Info().Msg("Transaction confirmed.")
+				if !msg.Successful {
+					msg.RevertData = w.revertData(ctx, &logger, rec)
+					msg.RevertReason = revertReason(msg.RevertData)
+				}
 
-			w.prod.Confirmed(msg)
+				err = w.inTx(ctx, func(dbTx *sql.Tx) error {
+					if err := w.prod.Confirmed(ctx, dbTx, msg); err != nil {
+						return err
+					}
 
-			_, err := activeTx.Delete(ctx, w.dbs.DBS().Writer)
+					if err := w.quotas.RecordSpend(ctx, dbTx, activeTx.ClientID, receipt.Fee, receipt.BlockTimestamp); err != nil {
+						return err
+					}
+
+					// Reverted calls stop early, so their usage would drag the
+					// statistics down.
+					if rec.Status == ethtypes.ReceiptStatusSuccessful {
+						if err := w.gasLimits.Record(ctx, dbTx, requestTo(activeTx), activeTx.Data, rec.GasUsed); err != nil {
+							return err
+						}
+					}
+
+					_, err := activeTx.Delete(ctx, dbTx)
+					return err
+				})
+			}
 			if err != nil {
 				return err
 			}
@@ -306,15 +574,39 @@ func (w *Watcher) Tick(ctx context.Context) error {
 		} else {
 			if rec.BlockHash != common.BytesToHash(activeTx.MinedBlockHash.Bytes) {
 				logger.Info().Msgf("Transaction moved from block %d to block %d.", activeTx.MinedBlockNumber.Int(nil), rec.BlockNumber)
-				activeTx.MinedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(rec.BlockNumber, 0))
-				activeTx.MinedBlockHash = null.BytesFrom(rec.BlockHash.Bytes())
-
-				_, err := activeTx.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(
-					models.MetaTransactionRequestColumns.MinedBlockNumber,
-					models.MetaTransactionRequestColumns.MinedBlockHash,
-					models.MetaTransactionRequestColumns.UpdatedAt,
-				))
-				return err
+
+				oldBlockNumber := activeTx.MinedBlockNumber.Int(nil)
+				oldBlockHash := common.BytesToHash(activeTx.MinedBlockHash.Bytes)
+
+				return w.inTx(ctx, func(dbTx *sql.Tx) error {
+					for _, req := range active {
+						req.MinedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(rec.BlockNumber, 0))
+						req.MinedBlockHash = null.BytesFrom(rec.BlockHash.Bytes())
+
+						_, err := req.Update(ctx, dbTx, boil.Whitelist(
+							models.MetaTransactionRequestColumns.MinedBlockNumber,
+							models.MetaTransactionRequestColumns.MinedBlockHash,
+							models.MetaTransactionRequestColumns.UpdatedAt,
+						))
+						if err != nil {
+							return err
+						}
+
+						msg := &status.ReorgedMsg{
+							ID:             req.ID,
+							Hash:           common.BytesToHash(req.Hash.Bytes),
+							OldBlockNumber: oldBlockNumber,
+							OldBlockHash:   oldBlockHash,
+							NewBlockNumber: rec.BlockNumber,
+							NewBlockHash:   rec.BlockHash,
+						}
+
+						if err := w.prod.Reorged(ctx, dbTx, msg); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
 			}
 			// Otherwise, we're just waiting for more confirmations.
 			return nil
@@ -322,13 +614,39 @@ func (w *Watcher) Tick(ctx context.Context) error {
 	}
 
 	// At this point, there's nothing in the table that's been submitted. Try to submit something.
-	submittedTxBlockAge.With(prometheus.Labels{"wallet": strconv.Itoa(w.walletIndex)}).Set(0)
+	submittedTxBlockAge.With(prometheus.Labels{"chain": w.chainID.String(), "wallet": strconv.Itoa(w.walletIndex)}).Set(0)
 
-	sendTx, err := models.MetaTransactionRequests(
-		qm.OrderBy(models.MetaTransactionRequestColumns.ID+" ASC"),
+	// Requests from clients that have spent their gas budget for the day wait
+	// until tomorrow.
+	now := time.Now()
+
+	overBudget, err := w.quotas.OverBudget(ctx, w.dbs.DBS().Reader, now)
+	if err != nil {
+		return err
+	}
+
+	if err := w.expire(ctx, now); err != nil {
+		return err
+	}
+
+	if err := w.release(ctx); err != nil {
+		return err
+	}
+
+	ready := []qm.QueryMod{
+		w.onChain(),
 		models.MetaTransactionRequestWhere.WalletIndex.EQ(w.walletIndex),
-		qm.Limit(1),
-	).One(ctx, w.dbs.DBS().Reader)
+		models.MetaTransactionRequestWhere.ClientID.NIN(overBudget),
+		models.MetaTransactionRequestWhere.DelayedAt.IsNull(),
+		qm.Expr(
+			models.MetaTransactionRequestWhere.NotBefore.IsNull(),
+			qm.Or2(models.MetaTransactionRequestWhere.NotBefore.LTE(null.TimeFrom(now))),
+		),
+		w.priorityOrder(),
+		qm.OrderBy(models.MetaTransactionRequestColumns.ID + " ASC"),
+	}
+
+	sendTx, err := models.MetaTransactionRequests(append(ready, qm.Limit(1))...).One(ctx, w.dbs.DBS().Reader)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil
@@ -336,6 +654,11 @@ func (w *Watcher) Tick(ctx context.Context) error {
 		return err
 	}
 
+	batch, err := w.batchFor(ctx, sendTx, ready)
+	if err != nil {
+		return err
+	}
+
 	head, err := w.client.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve latest block: %w", err)
@@ -344,11 +667,15 @@ func (w *Watcher) Tick(ctx context.Context) error {
 	headNum := head.Number
 	headNumFloat, _ := headNum.Float64()
 
-	latestBlock.Set(headNumFloat)
+	latestBlock.With(prometheus.Labels{"chain": w.chainID.String()}).Set(headNumFloat)
 
 	logger := w.logger.With().Int64("block", headNum.Int64()).Int("walletIndex", w.walletIndex).Logger()
 
-	logger = logger.With().Str("requestId", sendTx.ID).Str("contract", common.BytesToAddress(sendTx.To).Hex()).Logger()
+	logger = logger.With().Str("requestId", sendTx.ID).Str("contract", contractLabel(sendTx)).Logger()
+
+	if len(batch) > 1 {
+		logger = logger.With().Int("batchSize", len(batch)).Logger()
+	}
 
 	nonce, err := w.client.PendingNonceAt(ctx, w.sender.Address())
 	if err != nil {
@@ -359,21 +686,41 @@ func (w *Watcher) Tick(ctx context.Context) error {
 
 	// TODO(elffjs): Look at EIP-1559 stuff. Seems weird on Polygon and their oracles
 	// are weird.
-	gasPrice, err := w.client.SuggestGasPrice(ctx)
+	gasPrice, err := w.gasPrices.For(sendTx.Priority).GasPrice(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve gas price estimate: %w", err)
+		return err
 	}
 
-	gasPrice = new(big.Int).Mul(common.Big2, gasPrice)
+	if limit := w.ceiling(sendTx); limit != nil && gasPrice.Cmp(limit) > 0 {
+		return w.delay(ctx, &logger, []*models.MetaTransactionRequest{sendTx}, nil, gasPrice, limit)
+	}
+
+	// Others in the batch with a lower limit wait for a transaction of their own.
+	batch = slices.DeleteFunc(batch, func(req *models.MetaTransactionRequest) bool {
+		limit := w.ceiling(req)
+		return limit != nil && gasPrice.Cmp(limit) > 0
+	})
 
-	callMsg := ethereum.CallMsg{
-		From:     w.sender.Address(),
-		To:       Ref(common.BytesToAddress(sendTx.To)),
-		GasPrice: gasPrice,
-		Data:     sendTx.Data,
+	callMsg, err := w.callMsg(batch, gasPrice)
+	if err != nil {
+		return err
 	}
 
-	gasLimit, err := w.client.EstimateGas(ctx, callMsg)
+	gasLimit, err := w.gasLimit(ctx, sendTx, &callMsg)
+	if err != nil && len(batch) > 1 {
+		// Shouldn't happen, since every call is allowed to fail. Don't let the
+		// whole batch suffer for it.
+		logger.Err(err).Msg("Failed to estimate gas usage for batch, sending the first request alone.")
+
+		batch = batch[:1]
+
+		callMsg, err = w.callMsg(batch, gasPrice)
+		if err != nil {
+			return err
+		}
+
+		gasLimit, err = w.gasLimit(ctx, sendTx, &callMsg)
+	}
 	if err != nil {
 		logger.Err(err).Msg("Failed to estimate gas usage for transaction.")
 
@@ -388,31 +735,31 @@ func (w *Watcher) Tick(ctx context.Context) error {
 					outData = data
 				}
 			}
-		} else {
+		} else if !errors.As(err, new(*gaslimit.OverCeiling)) {
 			return fmt.Errorf("error estimating gas: %w", err)
 		}
 
-		w.prod.Failed(&status.FailedMsg{ID: sendTx.ID, Data: outData})
+		estimateErr := err
 
-		_, err := sendTx.Delete(ctx, w.dbs.DBS().Writer)
-		if err != nil {
-			return fmt.Errorf("failed to delete un-estimateable transaction: %w", err)
-		}
+		return w.inTx(ctx, func(dbTx *sql.Tx) error {
+			if err := w.prod.Failed(ctx, dbTx, failedEstimate(sendTx.ID, outData, estimateErr)); err != nil {
+				return err
+			}
 
-		return nil
-	}
+			if _, err := sendTx.Delete(ctx, dbTx); err != nil {
+				return fmt.Errorf("failed to delete un-estimateable transaction: %w", err)
+			}
 
-	gasLimit = 2 * gasLimit
+			return nil
+		})
+	}
 
-	txd := &ethtypes.LegacyTx{
-		Nonce:    nonce,
-		GasPrice: gasPrice,
-		Gas:      gasLimit,
-		To:       callMsg.To,
-		Data:     callMsg.Data,
+	simulation, ok, err := w.simulate(ctx, &logger, batch, callMsg, gasLimit)
+	if err != nil || !ok {
+		return err
 	}
 
-	tx := ethtypes.NewTx(txd)
+	tx := w.newTx(nonce, gasPrice, gasLimit, callMsg)
 
 	sigHash := signer.Hash(tx)
 	sigBytes, err := w.sender.Sign(ctx, sigHash)
@@ -432,32 +779,219 @@ func (w *Watcher) Tick(ctx context.Context) error {
 		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	sendTx.SubmittedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(headNum, 0))
-	sendTx.SubmittedBlockHash = null.BytesFrom(head.Hash().Bytes())
-	sendTx.Nonce = types.NewNullDecimal(new(decimal.Big).SetUint64(nonce))
-	sendTx.GasPrice = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(gasPrice, 0))
-	sendTx.Hash = null.BytesFrom(signedTx.Hash().Bytes())
-
-	_, err = sendTx.Update(ctx, w.dbs.DBS().Writer, boil.Whitelist(
-		cols.SubmittedBlockHash,
-		cols.Hash,
-		cols.SubmittedBlockNumber,
-		cols.Nonce,
-		cols.GasPrice,
-		cols.UpdatedAt,
-	))
+	return w.inTx(ctx, func(dbTx *sql.Tx) error {
+		for i, req := range batch {
+			req.SubmittedBlockNumber = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(headNum, 0))
+			req.SubmittedBlockHash = null.BytesFrom(head.Hash().Bytes())
+			req.Nonce = types.NewNullDecimal(new(decimal.Big).SetUint64(nonce))
+			req.GasPrice = types.NewNullDecimal(new(decimal.Big).SetBigMantScale(gasPrice, 0))
+			req.Hash = null.BytesFrom(signedTx.Hash().Bytes())
+			if len(batch) > 1 {
+				req.BatchIndex = null.IntFrom(i)
+			}
+
+			_, err := req.Update(ctx, dbTx, boil.Whitelist(
+				cols.SubmittedBlockHash,
+				cols.Hash,
+				cols.SubmittedBlockNumber,
+				cols.Nonce,
+				cols.GasPrice,
+				cols.BatchIndex,
+				cols.UpdatedAt,
+			))
+			if err != nil {
+				return err
+			}
+
+			err = w.prod.Submitted(ctx, dbTx, &status.SubmittedMsg{
+				ID:         req.ID,
+				Hash:       signedTx.Hash(),
+				Simulation: simulation,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// gasLimit returns the gas limit set in the request or, if there isn't one,
+// the one our policies give for the node's estimate. Errors come from the
+// estimate, or are an *gaslimit.OverCeiling. If access lists are on and one
+// lowers the estimate, it's set on callMsg.
+func (w *Watcher) gasLimit(ctx context.Context, req *models.MetaTransactionRequest, callMsg *ethereum.CallMsg) (uint64, error) {
+	if !req.GasLimit.IsZero() {
+		gasLimit, _ := req.GasLimit.Uint64()
+		return gasLimit, nil
+	}
+
+	if gasLimit, ok := w.gasLimits.Fixed(callMsg.To, callMsg.Data); ok {
+		return gasLimit, nil
+	}
+
+	estimate, err := w.client.EstimateGas(ctx, *callMsg)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	w.prod.Submitted(&status.SubmittedMsg{
-		ID:   sendTx.ID,
-		Hash: signedTx.Hash(),
-	})
+	if w.accessLists {
+		estimate = w.withAccessList(ctx, callMsg, estimate)
+	}
+
+	return w.gasLimits.Limit(ctx, w.dbs.DBS().Reader, callMsg.To, callMsg.Data, estimate)
+}
+
+// failedEstimate is the Failed message for a request whose transaction we
+// couldn't get a gas limit for. data is the revert data, if there is any.
+func failedEstimate(id string, data []byte, err error) *status.FailedMsg {
+	msg := &status.FailedMsg{ID: id, Data: data}
+
+	var over *gaslimit.OverCeiling
+	if errors.As(err, &over) {
+		msg.Reason = status.ReasonGasLimit
+		msg.Message = over.Error()
+	}
+
+	return msg
+}
+
+// requestTo returns the address the request calls, or nil if it deploys a
+// contract.
+func requestTo(req *models.MetaTransactionRequest) *common.Address {
+	if !req.To.Valid {
+		return nil
+	}
+	return Ref(common.BytesToAddress(req.To.Bytes))
+}
+
+// contractLabel is what we log as the contract for the request.
+func contractLabel(req *models.MetaTransactionRequest) string {
+	if !req.To.Valid {
+		return "deployment"
+	}
+	return common.BytesToAddress(req.To.Bytes).Hex()
+}
+
+// requestValue returns the amount of wei the request sends, or nil if it
+// doesn't send any.
+func requestValue(req *models.MetaTransactionRequest) *big.Int {
+	if req.Value.IsZero() {
+		return nil
+	}
+	return req.Value.Int(nil)
+}
+
+// expire drops the wallet's waiting requests whose deadlines have passed.
+// Requests that were already submitted are left alone: the transaction is out
+// there, so we have to see it through.
+func (w *Watcher) expire(ctx context.Context, now time.Time) error {
+	expired, err := models.MetaTransactionRequests(
+		w.onChain(),
+		models.MetaTransactionRequestWhere.WalletIndex.EQ(w.walletIndex),
+		models.MetaTransactionRequestWhere.SubmittedBlockNumber.IsNull(),
+		models.MetaTransactionRequestWhere.ExpiresAt.LTE(null.TimeFrom(now)),
+	).All(ctx, w.dbs.DBS().Reader)
+	if err != nil {
+		return fmt.Errorf("failed to load expired requests: %w", err)
+	}
+
+	for _, req := range expired {
+		w.logger.Info().Str("requestId", req.ID).Int("walletIndex", w.walletIndex).Msgf("Request expired at %s.", req.ExpiresAt.Time)
+
+		err := w.inTx(ctx, func(dbTx *sql.Tx) error {
+			if err := w.prod.Expired(ctx, dbTx, &status.ExpiredMsg{ID: req.ID, ExpiresAt: req.ExpiresAt.Time}); err != nil {
+				return err
+			}
+
+			if _, err := req.Delete(ctx, dbTx); err != nil {
+				return fmt.Errorf("failed to delete expired request: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		expiredTotal.Inc()
+	}
 
 	return nil
 }
 
+// onChain selects the requests for the watcher's chain. Requests without a
+// chain id predate multi-chain support, and belong to the default chain.
+func (w *Watcher) onChain() qm.QueryMod {
+	where := models.MetaTransactionRequestWhere.ChainID.EQ(null.Int64From(w.chainID.Int64()))
+	if !w.defaultChain {
+		return where
+	}
+	return qm.Expr(where, qm.Or2(models.MetaTransactionRequestWhere.ChainID.IsNull()))
+}
+
+// priorityOrder sorts waiting requests so that the highest priority comes first.
+// If aging is on, a request gains a point of priority for every period it has
+// spent waiting, so that a steady stream of urgent requests can't hold back the
+// rest forever.
+func (w *Watcher) priorityOrder() qm.QueryMod {
+	if w.priorityAging <= 0 {
+		return qm.OrderBy(cols.Priority + " DESC")
+	}
+
+	return qm.OrderBy(
+		fmt.Sprintf("%s + floor(extract(epoch FROM now() - %s) / ?) DESC", cols.Priority, cols.CreatedAt),
+		w.priorityAging.Seconds(),
+	)
+}
+
+// inTx runs f inside a database transaction, so that row changes and the status
+// events that describe them are committed together.
+func (w *Watcher) inTx(ctx context.Context, f func(dbTx *sql.Tx) error) error {
+	dbTx, err := w.dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback() //nolint:errcheck
+
+	if err := f(dbTx); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
+
+// receiptDetails pulls the block and cost information out of a receipt. The
+// receipt doesn't carry the block timestamp, so we have to look up the header.
+func (w *Watcher) receiptDetails(ctx context.Context, rec *ethtypes.Receipt) (*status.Receipt, error) {
+	header, err := w.client.HeaderByHash(ctx, rec.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve header for block %s: %w", rec.BlockHash, err)
+	}
+
+	fee := new(big.Int)
+	if rec.EffectiveGasPrice != nil {
+		fee.Mul(new(big.Int).SetUint64(rec.GasUsed), rec.EffectiveGasPrice)
+	}
+
+	out := &status.Receipt{
+		BlockNumber:       rec.BlockNumber,
+		BlockHash:         rec.BlockHash,
+		BlockTimestamp:    time.Unix(int64(header.Time), 0).UTC(),
+		TransactionIndex:  rec.TransactionIndex,
+		GasUsed:           rec.GasUsed,
+		EffectiveGasPrice: rec.EffectiveGasPrice,
+		Fee:               fee,
+	}
+
+	// Only set for deployments.
+	if rec.ContractAddress != (common.Address{}) {
+		out.ContractAddress = &rec.ContractAddress
+	}
+
+	return out, nil
+}
+
 func Ref[A any](a A) *A {
 	return &a
 }
@@ -3,24 +3,34 @@ package ticker
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/DIMO-Network/meta-transaction-processor/internal/gasprice"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/mocks"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/multicall"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/quota"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/sender"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
 	"github.com/DIMO-Network/meta-transaction-processor/internal/testcontract"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/trace"
 	"github.com/DIMO-Network/shared/db"
+	"github.com/IBM/sarama"
 	"github.com/docker/go-connections/nat"
+	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pressly/goose/v3"
 	"github.com/rs/zerolog"
 	"github.com/segmentio/ksuid"
@@ -28,7 +38,9 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	boiltypes "github.com/volatiletech/sqlboiler/v4/types"
 	"go.uber.org/mock/gomock"
 )
 
@@ -125,6 +137,9 @@ func (s *WatcherTestSuite) SetupTest() {
 	_, err := models.MetaTransactionRequests().DeleteAll(ctx, s.dbs.DBS().Writer)
 	s.Require().NoError(err)
 
+	_, err = models.ClientGasSpends().DeleteAll(ctx, s.dbs.DBS().Writer)
+	s.Require().NoError(err)
+
 	logger := zerolog.Nop()
 
 	deployAddr, deploySK := s.createAccount()
@@ -136,6 +151,9 @@ func (s *WatcherTestSuite) SetupTest() {
 		deployAddr: types.Account{
 			Balance: new(big.Int).Mul(big.NewInt(10_000), eth),
 		},
+		multicall.Address: types.Account{
+			Code: testcontract.MulticallCode,
+		},
 	})
 
 	s.client = s.backend.Client()
@@ -160,12 +178,15 @@ func (s *WatcherTestSuite) SetupTest() {
 		logger:             &logger,
 		confirmationBlocks: big.NewInt(3),
 		boostAfterBlocks:   big.NewInt(10),
+		gasPrices:          gasprice.NewSchedule(gasprice.NewNode(s.client, 200), nil),
 		prod:               s.producer,
 		dbs:                s.dbs,
 		client:             s.client,
 		sender:             sender,
 		chainID:            big.NewInt(1337),
 		walletIndex:        2,
+		defaultChain:       true,
+		window:             newChainWindow(windowSize),
 	}
 }
 
@@ -174,14 +195,14 @@ func (s *WatcherTestSuite) TestSubmitSuccess() {
 
 	mtr := models.MetaTransactionRequest{
 		ID:          ksuid.New().String(),
-		To:          s.contractAddr.Bytes(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
 		WalletIndex: 2,
 		Data:        common.FromHex("0x7050f4c0"),
 	}
 
 	subCapt := &ArgCaptor[*status.SubmittedMsg]{}
 
-	s.producer.EXPECT().Submitted(subCapt)
+	s.producer.EXPECT().Submitted(gomock.Any(), gomock.Any(), subCapt)
 
 	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
 	s.Require().NoError(err)
@@ -211,28 +232,42 @@ func (s *WatcherTestSuite) TestSubmitSuccess() {
 
 	s.backend.Commit()
 
-	s.producer.EXPECT().Mined(&status.MinedMsg{
-		ID:   mtr.ID,
-		Hash: txHash,
-	})
+	minedCapt := &ArgCaptor[*status.MinedMsg]{}
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), minedCapt)
 
 	err = s.w.Tick(ctx)
 	s.Require().NoError(err)
 
+	s.Equal(mtr.ID, minedCapt.Value().ID)
+	s.Equal(txHash, minedCapt.Value().Hash)
+
 	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
 	s.Require().NoError(err)
 
 	s.Equal(big.NewInt(3), mtr.MinedBlockNumber.Int(nil))
 
+	minedBlock, err := s.client.BlockByNumber(ctx, big.NewInt(3))
+	s.Require().NoError(err)
+
+	rec := minedCapt.Value().Receipt
+	s.Require().NotNil(rec)
+	s.Equal(big.NewInt(3), rec.BlockNumber)
+	s.Equal(minedBlock.Hash(), rec.BlockHash)
+	s.Equal(int64(minedBlock.Time()), rec.BlockTimestamp.Unix())
+	s.NotZero(rec.GasUsed)
+	s.Equal(new(big.Int).Mul(new(big.Int).SetUint64(rec.GasUsed), rec.EffectiveGasPrice), rec.Fee)
+
 	s.backend.Commit()
 	err = s.w.Tick(ctx)
 	s.Require().NoError(err)
 
-	s.producer.EXPECT().Confirmed(&status.ConfirmedMsg{
+	s.producer.EXPECT().Confirmed(gomock.Any(), gomock.Any(), &status.ConfirmedMsg{
 		ID:         mtr.ID,
 		Hash:       txHash,
 		Logs:       make([]*status.Log, 0),
 		Successful: true,
+		Receipt:    rec,
 	})
 
 	s.backend.Commit()
@@ -245,7 +280,7 @@ func (s *WatcherTestSuite) TestSubmitCustomErrorWithArgs() {
 
 	mtr := models.MetaTransactionRequest{
 		ID:          ksuid.New().String(),
-		To:          s.contractAddr.Bytes(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
 		WalletIndex: 2,
 		Data:        common.FromHex("0x4740a9ce"),
 	}
@@ -256,7 +291,7 @@ func (s *WatcherTestSuite) TestSubmitCustomErrorWithArgs() {
 
 	b = append(abi.Errors["ErrorOneArg"].ID.Bytes()[:4], b...)
 
-	s.producer.EXPECT().Failed(&status.FailedMsg{
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), &status.FailedMsg{
 		ID:   mtr.ID,
 		Data: b,
 	})
@@ -268,6 +303,1226 @@ func (s *WatcherTestSuite) TestSubmitCustomErrorWithArgs() {
 	s.Require().NoError(err)
 }
 
+// submit inserts the request and ticks once to send its transaction. It
+// returns the transaction hash.
+func (s *WatcherTestSuite) submit(mtr *models.MetaTransactionRequest) common.Hash {
+	ctx := context.Background()
+
+	subCapt := &ArgCaptor[*status.SubmittedMsg]{}
+
+	s.producer.EXPECT().Submitted(gomock.Any(), gomock.Any(), subCapt)
+
+	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Require().Equal(mtr.ID, subCapt.Value().ID)
+
+	return subCapt.Value().Hash
+}
+
+func (s *WatcherTestSuite) TestValueAndGasLimit() {
+	ctx := context.Background()
+
+	to, _ := s.createAccount()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(to.Bytes()),
+		WalletIndex: 2,
+		Value:       boiltypes.NewNullDecimal(new(decimal.Big).SetBigMantScale(eth, 0)),
+		GasLimit:    boiltypes.NewNullDecimal(new(decimal.Big).SetUint64(70_000)),
+	}
+
+	txHash := s.submit(&mtr)
+
+	tx, _, err := s.client.TransactionByHash(ctx, txHash)
+	s.Require().NoError(err)
+
+	s.Equal(eth, tx.Value())
+	s.EqualValues(70_000, tx.Gas())
+	s.Equal(&to, tx.To())
+
+	s.backend.Commit()
+
+	balance, err := s.client.BalanceAt(ctx, to, nil)
+	s.Require().NoError(err)
+	s.Equal(eth, balance)
+}
+
+func (s *WatcherTestSuite) TestDeployment() {
+	ctx := context.Background()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		WalletIndex: 2,
+		Data:        common.FromHex(testcontract.TestcontractMetaData.Bin),
+	}
+
+	txHash := s.submit(&mtr)
+
+	tx, _, err := s.client.TransactionByHash(ctx, txHash)
+	s.Require().NoError(err)
+	s.Nil(tx.To())
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	confCapt := &ArgCaptor[*status.ConfirmedMsg]{}
+
+	s.producer.EXPECT().Confirmed(gomock.Any(), gomock.Any(), confCapt)
+
+	s.confirmAfterMined()
+
+	s.True(confCapt.Value().Successful)
+
+	contractAddr := crypto.CreateAddress(s.relayAddr, tx.Nonce())
+	s.Equal(&contractAddr, confCapt.Value().Receipt.ContractAddress)
+
+	code, err := s.client.CodeAt(ctx, contractAddr, nil)
+	s.Require().NoError(err)
+	s.NotEmpty(code)
+}
+
+func (s *WatcherTestSuite) TestUnmined() {
+	ctx := context.Background()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	s.backend.Commit()
+
+	parent, err := s.client.HeaderByNumber(ctx, nil)
+	s.Require().NoError(err)
+
+	txHash := s.submit(&mtr)
+
+	s.backend.Commit()
+
+	minedCapt := &ArgCaptor[*status.MinedMsg]{}
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), minedCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	minedBlockHash := minedCapt.Value().Receipt.BlockHash
+
+	// Drop the block with the transaction in it.
+	err = s.backend.Fork(parent.Hash())
+	s.Require().NoError(err)
+
+	s.producer.EXPECT().Unmined(gomock.Any(), gomock.Any(), &status.UnminedMsg{
+		ID:             mtr.ID,
+		Hash:           txHash,
+		OldBlockNumber: big.NewInt(3),
+		OldBlockHash:   minedBlockHash,
+	})
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	s.True(mtr.MinedBlockNumber.IsZero())
+	s.False(mtr.MinedBlockHash.Valid)
+
+	// The transaction goes back into the pool and lands in the replacement
+	// block.
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), minedCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(txHash, minedCapt.Value().Hash)
+	s.Equal(big.NewInt(3), minedCapt.Value().Receipt.BlockNumber)
+	s.NotEqual(minedBlockHash, minedCapt.Value().Receipt.BlockHash)
+}
+
+func (s *WatcherTestSuite) TestReorged() {
+	ctx := context.Background()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	s.backend.Commit()
+
+	parent, err := s.client.HeaderByNumber(ctx, nil)
+	s.Require().NoError(err)
+
+	txHash := s.submit(&mtr)
+
+	s.backend.Commit()
+
+	minedCapt := &ArgCaptor[*status.MinedMsg]{}
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), minedCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	oldBlockHash := minedCapt.Value().Receipt.BlockHash
+
+	// Replace the block without ticking in between, so the transaction turns
+	// up in a different block at the same height.
+	err = s.backend.Fork(parent.Hash())
+	s.Require().NoError(err)
+
+	s.backend.Commit()
+
+	newBlock, err := s.client.BlockByNumber(ctx, big.NewInt(3))
+	s.Require().NoError(err)
+	s.Require().NotEqual(oldBlockHash, newBlock.Hash())
+
+	s.producer.EXPECT().Reorged(gomock.Any(), gomock.Any(), &status.ReorgedMsg{
+		ID:             mtr.ID,
+		Hash:           txHash,
+		OldBlockNumber: big.NewInt(3),
+		OldBlockHash:   oldBlockHash,
+		NewBlockNumber: big.NewInt(3),
+		NewBlockHash:   newBlock.Hash(),
+	})
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	s.Equal(newBlock.Hash().Bytes(), mtr.MinedBlockHash.Bytes)
+}
+
+func (s *WatcherTestSuite) TestFinalitySafe() {
+	ctx := context.Background()
+
+	// The simulated chain calls its head safe, so counting would be slower.
+	s.w.finality = FinalitySafe
+	s.w.confirmationBlocks = big.NewInt(100)
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	txHash := s.submit(&mtr)
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	confCapt := &ArgCaptor[*status.ConfirmedMsg]{}
+
+	s.producer.EXPECT().Confirmed(gomock.Any(), gomock.Any(), confCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(mtr.ID, confCapt.Value().ID)
+	s.Equal(txHash, confCapt.Value().Hash)
+	s.True(confCapt.Value().Successful)
+}
+
+func (s *WatcherTestSuite) TestFinalityFinalized() {
+	ctx := context.Background()
+
+	// The simulated chain finalizes every 32 blocks.
+	s.w.finality = FinalityFinalized
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	s.submit(&mtr)
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	// Well past the confirmation count, but not yet finalized.
+	for {
+		head, err := s.client.HeaderByNumber(ctx, nil)
+		s.Require().NoError(err)
+		if head.Number.Uint64() == 31 {
+			break
+		}
+
+		s.backend.Commit()
+		err = s.w.Tick(ctx)
+		s.Require().NoError(err)
+	}
+
+	confCapt := &ArgCaptor[*status.ConfirmedMsg]{}
+
+	s.producer.EXPECT().Confirmed(gomock.Any(), gomock.Any(), confCapt)
+
+	s.backend.Commit()
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(mtr.ID, confCapt.Value().ID)
+}
+
+func (s *WatcherTestSuite) TestStaleReceipt() {
+	ctx := context.Background()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	s.backend.Commit()
+
+	parent, err := s.client.HeaderByNumber(ctx, nil)
+	s.Require().NoError(err)
+
+	txHash := s.submit(&mtr)
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	oldRec, err := s.client.TransactionReceipt(ctx, txHash)
+	s.Require().NoError(err)
+
+	err = s.backend.Fork(parent.Hash())
+	s.Require().NoError(err)
+
+	for range s.w.confirmationBlocks.Int64() + 1 {
+		s.backend.Commit()
+	}
+
+	// The node still hands out the receipt from the block that was replaced.
+	// It matches what we reported, but isn't canonical any more, so nothing
+	// is confirmed.
+	s.w.client = &staleReceipts{EthClient: s.client, rec: oldRec}
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	exists, err := models.MetaTransactionRequestExists(ctx, s.dbs.DBS().Reader, mtr.ID)
+	s.Require().NoError(err)
+	s.True(exists)
+}
+
+func (s *WatcherTestSuite) TestOutboxRelay() {
+	ctx := context.Background()
+
+	s.w.prod = status.NewOutbox()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	// The Submitted event is written in the same transaction as the row.
+	events, err := models.OutboxEvents(models.OutboxEventWhere.RequestID.EQ(mtr.ID)).All(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+
+	var event struct {
+		Data struct {
+			RequestID   string `json:"requestId"`
+			Type        string `json:"type"`
+			Transaction struct {
+				Hash common.Hash `json:"hash"`
+			} `json:"transaction"`
+		} `json:"data"`
+	}
+
+	err = json.Unmarshal(events[0].Payload, &event)
+	s.Require().NoError(err)
+
+	s.Equal(mtr.ID, event.Data.RequestID)
+	s.Equal("Submitted", event.Data.Type)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	s.Equal(common.BytesToHash(mtr.Hash.Bytes), event.Data.Transaction.Hash)
+
+	const topic = "topic.transaction.request.status"
+
+	broker := sarama.NewMockBroker(s.T(), 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(s.T()).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(s.T()),
+	})
+
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	s.Require().NoError(err)
+	defer client.Close()
+
+	logger := zerolog.Nop()
+
+	relay, err := status.NewRelay(topic, client, s.dbs, &logger)
+	s.Require().NoError(err)
+
+	relayCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go relay.Run(relayCtx)
+
+	// Events are deleted once Kafka has them.
+	s.Eventually(func() bool {
+		n, err := models.OutboxEvents(models.OutboxEventWhere.RequestID.EQ(mtr.ID)).Count(ctx, s.dbs.DBS().Reader)
+		return err == nil && n == 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	var produced int
+	for _, rr := range broker.History() {
+		if _, ok := rr.Request.(*sarama.ProduceRequest); ok {
+			produced++
+		}
+	}
+
+	s.NotZero(produced)
+}
+
+func (s *WatcherTestSuite) TestPriorityOrder() {
+	ctx := context.Background()
+
+	low := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	err := low.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	high := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+		Priority:    5,
+	}
+
+	err = high.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	subCapt := &ArgCaptor[*status.SubmittedMsg]{}
+
+	s.producer.EXPECT().Submitted(gomock.Any(), gomock.Any(), subCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	// The later request goes first.
+	s.Equal(high.ID, subCapt.Value().ID)
+}
+
+func (s *WatcherTestSuite) TestPriorityAging() {
+	ctx := context.Background()
+
+	s.w.priorityAging = time.Minute
+
+	// Ten minutes of waiting makes up for five points of priority.
+	old := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+		CreatedAt:   time.Now().Add(-10 * time.Minute),
+	}
+
+	err := old.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	high := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+		Priority:    5,
+	}
+
+	err = high.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	subCapt := &ArgCaptor[*status.SubmittedMsg]{}
+
+	s.producer.EXPECT().Submitted(gomock.Any(), gomock.Any(), subCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(old.ID, subCapt.Value().ID)
+}
+
+func (s *WatcherTestSuite) TestExpired() {
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(-time.Minute)
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+		ExpiresAt:   null.TimeFrom(expiresAt),
+	}
+
+	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	expCapt := &ArgCaptor[*status.ExpiredMsg]{}
+
+	s.producer.EXPECT().Expired(gomock.Any(), gomock.Any(), expCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(mtr.ID, expCapt.Value().ID)
+	s.WithinDuration(expiresAt, expCapt.Value().ExpiresAt, time.Millisecond)
+
+	exists, err := models.MetaTransactionRequestExists(ctx, s.dbs.DBS().Reader, mtr.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+// sendDirect signs and sends a call to the test contract from the relay
+// wallet, bypassing the watcher.
+func (s *WatcherTestSuite) sendDirect(gasPrice *big.Int, data string) *types.Transaction {
+	ctx := context.Background()
+
+	tx, err := types.SignNewTx(s.relaySK, types.LatestSignerForChainID(big.NewInt(1337)), &types.LegacyTx{
+		Nonce:    0,
+		To:       &s.contractAddr,
+		Gas:      100_000,
+		GasPrice: gasPrice,
+		Data:     common.FromHex(data),
+	})
+	s.Require().NoError(err)
+
+	err = s.client.SendTransaction(ctx, tx)
+	s.Require().NoError(err)
+
+	return tx
+}
+
+// insertSubmitted stores the request as if the watcher had sent it in block 1
+// with the given hash, nonce zero and gas price.
+func (s *WatcherTestSuite) insertSubmitted(mtr *models.MetaTransactionRequest, hash common.Hash, gasPrice *big.Int) {
+	ctx := context.Background()
+
+	block, err := s.client.HeaderByNumber(ctx, big.NewInt(1))
+	s.Require().NoError(err)
+
+	mtr.SubmittedBlockNumber = boiltypes.NewNullDecimal(new(decimal.Big).SetUint64(1))
+	mtr.SubmittedBlockHash = null.BytesFrom(block.Hash().Bytes())
+	mtr.Nonce = boiltypes.NewNullDecimal(new(decimal.Big).SetUint64(0))
+	mtr.GasPrice = boiltypes.NewNullDecimal(new(decimal.Big).SetBigMantScale(gasPrice, 0))
+	mtr.Hash = null.BytesFrom(hash.Bytes())
+
+	err = mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+}
+
+// confirmAfterMined commits enough blocks after the one with the transaction,
+// ticking after each, for the transaction to be confirmed.
+func (s *WatcherTestSuite) confirmAfterMined() {
+	ctx := context.Background()
+
+	for range s.w.confirmationBlocks.Int64() {
+		s.backend.Commit()
+		err := s.w.Tick(ctx)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *WatcherTestSuite) TestBatchOneReverts() {
+	ctx := context.Background()
+
+	// The node can't trace, so the outcomes come from a replay.
+	s.w.rpc = s.rpcClient()
+	s.w.batching = &BatchConfig{
+		Multicall: multicall.Address,
+		Contracts: map[common.Address]struct{}{s.contractAddr: {}},
+		MaxSize:   5,
+	}
+
+	quotas, err := quota.New(0, "", "")
+	s.Require().NoError(err)
+	s.w.quotas = quotas
+
+	succeed := &models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0xf583dabb"), // succeedOneEvent()
+		ClientID:    "succeed",
+	}
+	revert := &models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x185c38a4"), // revertWithMessage()
+		ClientID:    "revert",
+	}
+
+	for _, mtr := range []*models.MetaTransactionRequest{succeed, revert} {
+		err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+		s.Require().NoError(err)
+	}
+
+	var submitted []*status.SubmittedMsg
+
+	s.producer.EXPECT().Submitted(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Do(func(_ context.Context, _ boil.ContextExecutor, msg *status.SubmittedMsg) {
+		submitted = append(submitted, msg)
+	})
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Require().Len(submitted, 2)
+	s.Equal(submitted[0].Hash, submitted[1].Hash)
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	confCapt := &ArgCaptor[*status.ConfirmedMsg]{}
+	failCapt := &ArgCaptor[*status.FailedMsg]{}
+
+	s.producer.EXPECT().Confirmed(gomock.Any(), gomock.Any(), confCapt)
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), failCapt)
+
+	s.confirmAfterMined()
+
+	conf := confCapt.Value()
+	s.Equal(succeed.ID, conf.ID)
+	s.Equal(submitted[0].Hash, conf.Hash)
+	s.True(conf.Successful)
+	s.Require().Len(conf.Logs, 1)
+	s.Equal(s.contractAddr, conf.Logs[0].Address)
+	s.Equal(crypto.Keccak256Hash([]byte("EventOneArg(uint256)")), conf.Logs[0].Topics[0])
+
+	fail := failCapt.Value()
+	s.Equal(revert.ID, fail.ID)
+	s.Equal(submitted[0].Hash, fail.Hash)
+	s.Equal("My require message", revertReason(fail.Data))
+	s.Equal(conf.Receipt, fail.Receipt)
+
+	// The first request in the batch picks up the odd wei.
+	fee := conf.Receipt.Fee
+	share, rem := new(big.Int).QuoRem(fee, big.NewInt(2), new(big.Int))
+	want := map[string]*big.Int{submitted[0].ID: new(big.Int).Add(share, rem), submitted[1].ID: share}
+
+	for _, mtr := range []*models.MetaTransactionRequest{succeed, revert} {
+		spend, err := models.ClientGasSpends(models.ClientGasSpendWhere.ClientID.EQ(mtr.ClientID)).One(ctx, s.dbs.DBS().Reader)
+		s.Require().NoError(err)
+		s.Equal(want[mtr.ID].String(), spend.Wei.Int(nil).String(), mtr.ClientID)
+	}
+
+	n, err := models.MetaTransactionRequests().Count(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(n)
+}
+
+func (s *WatcherTestSuite) TestBatchOutcomesUnknown() {
+	ctx := context.Background()
+
+	// Without a trace or a replay, there's no telling which calls succeeded.
+	s.w.rpc = noTracing{}
+
+	gasPrice := big.NewInt(2_000_000_000)
+	tx := s.sendDirect(gasPrice, "0x7050f4c0")
+
+	batch := make([]*models.MetaTransactionRequest, 2)
+	for i := range batch {
+		batch[i] = &models.MetaTransactionRequest{
+			ID:          ksuid.New().String(),
+			To:          null.BytesFrom(s.contractAddr.Bytes()),
+			WalletIndex: 2,
+			Data:        common.FromHex("0x7050f4c0"),
+			BatchIndex:  null.IntFrom(i),
+		}
+		s.insertSubmitted(batch[i], tx.Hash(), gasPrice)
+	}
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	for range s.w.confirmationBlocks.Int64() {
+		s.backend.Commit()
+	}
+
+	// The batch holds up the wallet until we give up on it.
+	for range batchAttempts - 1 {
+		err := s.w.Tick(ctx)
+		s.Require().Error(err)
+	}
+
+	var failed []*status.FailedMsg
+
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Do(func(_ context.Context, _ boil.ContextExecutor, msg *status.FailedMsg) {
+		failed = append(failed, msg)
+	})
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Require().Len(failed, 2)
+
+	for i, msg := range failed {
+		s.Equal(batch[i].ID, msg.ID)
+		s.Equal(status.ReasonBatch, msg.Reason)
+		s.Equal(tx.Hash(), msg.Hash)
+		s.Require().NotNil(msg.Receipt)
+		s.Equal(big.NewInt(2), msg.Receipt.BlockNumber)
+	}
+
+	n, err := models.MetaTransactionRequests().Count(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(n)
+}
+
+func (s *WatcherTestSuite) TestBundleWithoutEvent() {
+	ctx := context.Background()
+
+	// Stands in for a handleOps call in which the EntryPoint didn't report on
+	// the operation.
+	gasPrice := big.NewInt(2_000_000_000)
+	tx := s.sendDirect(gasPrice, "0x7050f4c0")
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+		UserOpHash:  null.BytesFrom(crypto.Keccak256([]byte("op"))),
+	}
+	s.insertSubmitted(&mtr, tx.Hash(), gasPrice)
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	failCapt := &ArgCaptor[*status.FailedMsg]{}
+
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), failCapt)
+
+	s.confirmAfterMined()
+
+	s.Equal(mtr.ID, failCapt.Value().ID)
+	s.Equal(status.ReasonUserOperation, failCapt.Value().Reason)
+	s.Equal("No UserOperationEvent for the operation.", failCapt.Value().Message)
+	s.Equal(tx.Hash(), failCapt.Value().Hash)
+	s.NotNil(failCapt.Value().Receipt)
+
+	exists, err := models.MetaTransactionRequestExists(ctx, s.dbs.DBS().Reader, mtr.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *WatcherTestSuite) TestBundleReverted() {
+	ctx := context.Background()
+
+	s.w.rpc = s.rpcClient()
+
+	// Stands in for a handleOps call that reverted.
+	gasPrice := big.NewInt(2_000_000_000)
+	tx := s.sendDirect(gasPrice, "0x185c38a4") // revertWithMessage()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x185c38a4"),
+		UserOpHash:  null.BytesFrom(crypto.Keccak256([]byte("op"))),
+	}
+	s.insertSubmitted(&mtr, tx.Hash(), gasPrice)
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	confCapt := &ArgCaptor[*status.ConfirmedMsg]{}
+
+	s.producer.EXPECT().Confirmed(gomock.Any(), gomock.Any(), confCapt)
+
+	s.confirmAfterMined()
+
+	s.Equal(mtr.ID, confCapt.Value().ID)
+	s.False(confCapt.Value().Successful)
+	s.NotEmpty(confCapt.Value().RevertData)
+	s.Equal("My require message", confCapt.Value().RevertReason)
+
+	exists, err := models.MetaTransactionRequestExists(ctx, s.dbs.DBS().Reader, mtr.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *WatcherTestSuite) TestRevertedOnChain() {
+	ctx := context.Background()
+
+	// The simulated node can't trace, so the call is replayed.
+	s.w.rpc = s.rpcClient()
+
+	// Estimation would have caught the revert, so send it ourselves.
+	gasPrice := big.NewInt(2_000_000_000)
+	tx := s.sendDirect(gasPrice, "0x185c38a4") // revertWithMessage()
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x185c38a4"),
+	}
+	s.insertSubmitted(&mtr, tx.Hash(), gasPrice)
+
+	s.backend.Commit()
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	confCapt := &ArgCaptor[*status.ConfirmedMsg]{}
+
+	s.producer.EXPECT().Confirmed(gomock.Any(), gomock.Any(), confCapt)
+
+	s.confirmAfterMined()
+
+	s.Equal(mtr.ID, confCapt.Value().ID)
+	s.Equal(tx.Hash(), confCapt.Value().Hash)
+	s.False(confCapt.Value().Successful)
+	s.Empty(confCapt.Value().Logs)
+	s.Equal("My require message", revertReason(confCapt.Value().RevertData))
+	s.Equal("My require message", confCapt.Value().RevertReason)
+}
+
+func (s *WatcherTestSuite) TestDelayedAboveCeiling() {
+	ctx := context.Background()
+
+	s.w.maxGasPrice = big.NewInt(1)
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	delCapt := &ArgCaptor[*status.DelayedMsg]{}
+
+	s.producer.EXPECT().Delayed(gomock.Any(), gomock.Any(), delCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(mtr.ID, delCapt.Value().ID)
+	s.Nil(delCapt.Value().Hash)
+	s.Equal(big.NewInt(1), delCapt.Value().MaxGasPrice)
+	s.Positive(delCapt.Value().GasPrice.Cmp(big.NewInt(1)))
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.True(mtr.DelayedAt.Valid)
+
+	// Still too expensive, and the request was already reported as waiting.
+	s.backend.Commit()
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	// Once the price is under the ceiling, the request goes out.
+	s.w.maxGasPrice = nil
+
+	subCapt := &ArgCaptor[*status.SubmittedMsg]{}
+
+	s.producer.EXPECT().Submitted(gomock.Any(), gomock.Any(), subCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(mtr.ID, subCapt.Value().ID)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.False(mtr.DelayedAt.Valid)
+}
+
+func (s *WatcherTestSuite) TestEscalationStep() {
+	ctx := context.Background()
+
+	s.w.escalation = Escalation{{AfterBlocks: 2, Percent: 50}}
+
+	// Never broadcast, so it won't be mined.
+	oldHash := common.HexToHash("0x01")
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+	s.insertSubmitted(&mtr, oldHash, big.NewInt(2_000_000_000))
+
+	// Not due yet.
+	s.backend.Commit()
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.backend.Commit()
+
+	boostCapt := &ArgCaptor[*status.BoostedMsg]{}
+
+	s.producer.EXPECT().Boosted(gomock.Any(), gomock.Any(), boostCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	newHash := boostCapt.Value().NewHash
+
+	s.Equal(mtr.ID, boostCapt.Value().ID)
+	s.Equal(oldHash, boostCapt.Value().OldHash)
+	s.Equal(big.NewInt(3_000_000_000), boostCapt.Value().GasPrice)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+
+	s.Equal(1, mtr.BoostStep)
+	s.Equal(newHash.Bytes(), mtr.Hash.Bytes)
+	s.Equal(big.NewInt(3), mtr.BoostedBlockNumber.Int(nil))
+	s.False(mtr.CanceledAt.Valid)
+
+	s.backend.Commit()
+
+	tx, pending, err := s.client.TransactionByHash(ctx, newHash)
+	s.Require().NoError(err)
+
+	s.False(pending)
+	s.Equal(s.contractAddr, *tx.To())
+	s.Equal(big.NewInt(3_000_000_000), tx.GasPrice())
+}
+
+func (s *WatcherTestSuite) TestEscalationCancel() {
+	ctx := context.Background()
+
+	s.w.escalation = Escalation{{AfterBlocks: 2, Cancel: true}}
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+	s.insertSubmitted(&mtr, common.HexToHash("0x01"), big.NewInt(2_000_000_000))
+
+	s.backend.Commit()
+	s.backend.Commit()
+
+	boostCapt := &ArgCaptor[*status.BoostedMsg]{}
+
+	s.producer.EXPECT().Boosted(gomock.Any(), gomock.Any(), boostCapt)
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(big.NewInt(2_400_000_000), boostCapt.Value().GasPrice)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.True(mtr.CanceledAt.Valid)
+
+	s.backend.Commit()
+
+	// The replacement sends nothing to ourselves.
+	tx, pending, err := s.client.TransactionByHash(ctx, boostCapt.Value().NewHash)
+	s.Require().NoError(err)
+
+	s.False(pending)
+	s.Equal(s.relayAddr, *tx.To())
+	s.Empty(tx.Data())
+	s.Zero(tx.Value().Sign())
+
+	s.producer.EXPECT().Mined(gomock.Any(), gomock.Any(), gomock.Any())
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), &status.FailedMsg{
+		ID:      mtr.ID,
+		Reason:  status.ReasonCanceled,
+		Message: "Canceled after 1 escalation steps.",
+	})
+
+	s.confirmAfterMined()
+}
+
+func (s *WatcherTestSuite) TestEscalationCancelUnmined() {
+	ctx := context.Background()
+
+	s.w.boostAfterBlocks = big.NewInt(2)
+	s.w.escalation = Escalation{{AfterBlocks: 2, Cancel: true}}
+
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+	s.insertSubmitted(&mtr, common.HexToHash("0x01"), big.NewInt(2_000_000_000))
+
+	s.backend.Commit()
+	s.backend.Commit()
+
+	cancelCapt := &ArgCaptor[*status.BoostedMsg]{}
+
+	s.producer.EXPECT().Boosted(gomock.Any(), gomock.Any(), cancelCapt)
+
+	err := s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(big.NewInt(2_400_000_000), cancelCapt.Value().GasPrice)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	canceledAt := mtr.CanceledAt.Time
+
+	// The cancellation never makes it into a block.
+	s.backend.Rollback()
+
+	s.backend.Commit()
+	s.backend.Commit()
+
+	boostCapt := &ArgCaptor[*status.BoostedMsg]{}
+
+	s.producer.EXPECT().Boosted(gomock.Any(), gomock.Any(), boostCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(cancelCapt.Value().NewHash, boostCapt.Value().OldHash)
+	s.Equal(big.NewInt(2_640_000_000), boostCapt.Value().GasPrice)
+
+	err = mtr.Reload(ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Equal(1, mtr.BoostStep)
+	s.WithinDuration(canceledAt, mtr.CanceledAt.Time, time.Millisecond)
+
+	s.backend.Commit()
+
+	// Still a cancellation.
+	tx, pending, err := s.client.TransactionByHash(ctx, boostCapt.Value().NewHash)
+	s.Require().NoError(err)
+
+	s.False(pending)
+	s.Equal(s.relayAddr, *tx.To())
+	s.Empty(tx.Data())
+}
+
+// expecting returns a request to call succeedNoEvents that expects
+// EventOneArg from the test contract.
+func (s *WatcherTestSuite) expecting() *models.MetaTransactionRequest {
+	topic := crypto.Keccak256Hash([]byte("EventOneArg(uint256)"))
+
+	mtr := &models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	err := mtr.Expectation.Marshal(&trace.Expectation{
+		Logs: []*trace.ExpectedLog{{Address: &s.contractAddr, Topics: []*common.Hash{&topic}}},
+	})
+	s.Require().NoError(err)
+
+	return mtr
+}
+
+func (s *WatcherTestSuite) TestSimulationMismatch() {
+	ctx := context.Background()
+
+	// The call succeeds, but without the log.
+	s.w.rpc = tracing{frame: &trace.Frame{Type: "CALL", From: s.relayAddr, To: &s.contractAddr, GasUsed: 21_500}}
+
+	mtr := s.expecting()
+
+	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), &status.FailedMsg{
+		ID:      mtr.ID,
+		Reason:  status.ReasonSimulation,
+		Message: "expected log 0 wasn't emitted",
+	})
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	// Nothing went out.
+	nonce, err := s.client.PendingNonceAt(ctx, s.relayAddr)
+	s.Require().NoError(err)
+	s.Zero(nonce)
+
+	exists, err := models.MetaTransactionRequestExists(ctx, s.dbs.DBS().Reader, mtr.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *WatcherTestSuite) TestSimulationWithoutTracing() {
+	ctx := context.Background()
+
+	s.w.rpc = noTracing{}
+	s.w.simulateAll = true
+
+	// Without an expectation, the gas estimate is enough.
+	mtr := models.MetaTransactionRequest{
+		ID:          ksuid.New().String(),
+		To:          null.BytesFrom(s.contractAddr.Bytes()),
+		WalletIndex: 2,
+		Data:        common.FromHex("0x7050f4c0"),
+	}
+
+	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	subCapt := &ArgCaptor[*status.SubmittedMsg]{}
+
+	s.producer.EXPECT().Submitted(gomock.Any(), gomock.Any(), subCapt)
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(mtr.ID, subCapt.Value().ID)
+	s.Nil(subCapt.Value().Simulation)
+}
+
+func (s *WatcherTestSuite) TestExpectationWithoutTracing() {
+	ctx := context.Background()
+
+	s.w.rpc = noTracing{}
+
+	mtr := s.expecting()
+
+	err := mtr.Insert(ctx, s.dbs.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	// There's no way to check the expectation.
+	s.producer.EXPECT().Failed(gomock.Any(), gomock.Any(), &status.FailedMsg{
+		ID:      mtr.ID,
+		Reason:  status.ReasonSimulation,
+		Message: "Couldn't trace the call to check the expectation.",
+	})
+
+	err = s.w.Tick(ctx)
+	s.Require().NoError(err)
+
+	nonce, err := s.client.PendingNonceAt(ctx, s.relayAddr)
+	s.Require().NoError(err)
+	s.Zero(nonce)
+}
+
+// rpcClient digs the RPC client out of the simulated backend's client, which
+// hides it. The simulated node has no debug API.
+func (s *WatcherTestSuite) rpcClient() *rpc.Client {
+	return reflect.ValueOf(s.client).Field(0).Interface().(*ethclient.Client).Client()
+}
+
+// tracing is a node that gives the same trace for every call.
+type tracing struct {
+	frame *trace.Frame
+}
+
+func (t tracing) CallContext(_ context.Context, result any, method string, _ ...any) error {
+	if method != "debug_traceCall" {
+		return fmt.Errorf("the method %s does not exist/is not available", method)
+	}
+
+	b, err := json.Marshal(t.frame)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+// staleReceipts serves the same receipt for every transaction.
+type staleReceipts struct {
+	EthClient
+	rec *types.Receipt
+}
+
+func (c *staleReceipts) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return c.rec, nil
+}
+
+// noTracing is a node without the debug API.
+type noTracing struct{}
+
+func (noTracing) CallContext(_ context.Context, _ any, method string, _ ...any) error {
+	return fmt.Errorf("the method %s does not exist/is not available", method)
+}
+
 type ArgCaptor[A any] struct {
 	value A
 }
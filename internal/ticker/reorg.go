@@ -0,0 +1,128 @@
+package ticker
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// windowSize is the number of recent blocks whose canonical hashes each
+// watcher remembers.
+const windowSize = 128
+
+// chainWindow remembers the canonical hashes of a run of recent blocks, so
+// that we can tell when the chain was rewritten between ticks, however many
+// blocks went by.
+type chainWindow struct {
+	size   uint64
+	hashes map[uint64]common.Hash
+	// low and high are the lowest and highest blocks we know. Everything in
+	// between is known too.
+	low, high uint64
+}
+
+func newChainWindow(size uint64) *chainWindow {
+	return &chainWindow{size: size, hashes: make(map[uint64]common.Hash)}
+}
+
+// update records head as the tip of the canonical chain. It walks back from
+// head, following parent hashes and fetching blocks we haven't seen, until it
+// reaches a block we already know with the right hash. It returns the number
+// of the lowest known block that turned out to have changed, or nil if there
+// was no reorg.
+func (c *chainWindow) update(ctx context.Context, client EthClient, head *ethtypes.Header) (*big.Int, error) {
+	n := head.Number.Uint64()
+
+	// After a long gap there's nothing useful to compare against. If the chain
+	// went back past everything we know, all of it changed.
+	if len(c.hashes) == 0 || n > c.high+c.size || n < c.low {
+		var forked *big.Int
+		if len(c.hashes) != 0 && n < c.low {
+			forked = new(big.Int).SetUint64(c.low)
+		}
+
+		clear(c.hashes)
+		c.hashes[n] = head.Hash()
+		c.low, c.high = n, n
+		return forked, nil
+	}
+
+	var forked *big.Int
+	mark := func(m uint64) {
+		if forked == nil || m < forked.Uint64() {
+			forked = new(big.Int).SetUint64(m)
+		}
+	}
+
+	// A shorter chain may have replaced the blocks above the new head.
+	for m := c.high; m > n; m-- {
+		if _, ok := c.hashes[m]; ok {
+			delete(c.hashes, m)
+			mark(m)
+		}
+	}
+
+	if old, ok := c.hashes[n]; ok && old != head.Hash() {
+		mark(n)
+	}
+	c.hashes[n] = head.Hash()
+
+	floor := c.low
+	if n >= c.size && n-c.size+1 > floor {
+		floor = n - c.size + 1
+	}
+
+	parent := head.ParentHash
+	for m := n; m > floor; {
+		m--
+
+		if old, ok := c.hashes[m]; ok {
+			if old == parent {
+				break
+			}
+			mark(m)
+		}
+
+		h, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(m))
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve block %d: %w", m, err)
+		}
+
+		c.hashes[m] = h.Hash()
+		parent = h.ParentHash
+	}
+
+	for m := c.low; m < floor; m++ {
+		delete(c.hashes, m)
+	}
+	c.low, c.high = floor, n
+
+	return forked, nil
+}
+
+// hash returns the canonical hash of the block, if it's in the window.
+func (c *chainWindow) hash(number *big.Int) (common.Hash, bool) {
+	if !number.IsUint64() {
+		return common.Hash{}, false
+	}
+	h, ok := c.hashes[number.Uint64()]
+	return h, ok
+}
+
+// canonicalHash returns the hash of the canonical block at the given height,
+// from the window if possible.
+func (w *Watcher) canonicalHash(ctx context.Context, number *big.Int) (common.Hash, error) {
+	if h, ok := w.window.hash(number); ok {
+		return h, nil
+	}
+
+	head, err := w.client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to retrieve block %d: %w", number, err)
+	}
+
+	return head.Hash(), nil
+}
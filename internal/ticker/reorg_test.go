@@ -0,0 +1,97 @@
+package ticker
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain serves headers from a canonical chain that can be rewritten.
+type fakeChain struct {
+	EthClient
+	headers []*ethtypes.Header
+}
+
+// extend appends blocks to the chain from height from onwards, replacing any
+// that were there. fork tells the new blocks apart from the old ones.
+func (c *fakeChain) extend(from, to uint64, fork byte) {
+	c.headers = c.headers[:from]
+	for n := from; n <= to; n++ {
+		h := &ethtypes.Header{Number: new(big.Int).SetUint64(n), Extra: []byte{fork}}
+		if n > 0 {
+			h.ParentHash = c.headers[n-1].Hash()
+		}
+		c.headers = append(c.headers, h)
+	}
+}
+
+func (c *fakeChain) head() *ethtypes.Header {
+	return c.headers[len(c.headers)-1]
+}
+
+func (c *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*ethtypes.Header, error) {
+	return c.headers[number.Uint64()], nil
+}
+
+func TestChainWindow(t *testing.T) {
+	ctx := context.Background()
+
+	chain := &fakeChain{}
+	chain.extend(0, 100, 0)
+
+	win := newChainWindow(16)
+
+	forked, err := win.update(ctx, chain, chain.head())
+	require.NoError(t, err)
+	assert.Nil(t, forked)
+
+	// A few blocks go by between ticks.
+	chain.extend(101, 103, 0)
+
+	forked, err = win.update(ctx, chain, chain.head())
+	require.NoError(t, err)
+	assert.Nil(t, forked)
+
+	h, ok := win.hash(big.NewInt(102))
+	require.True(t, ok)
+	assert.Equal(t, chain.headers[102].Hash(), h)
+
+	// Blocks from 101 onwards are replaced, and the chain moves on.
+	chain.extend(101, 106, 1)
+
+	forked, err = win.update(ctx, chain, chain.head())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(101), forked)
+
+	for n := int64(100); n <= 106; n++ {
+		h, ok := win.hash(big.NewInt(n))
+		require.True(t, ok)
+		assert.Equal(t, chain.headers[n].Hash(), h)
+	}
+
+	// A shorter chain replaces the tip.
+	chain.extend(105, 105, 2)
+
+	forked, err = win.update(ctx, chain, chain.head())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(105), forked)
+
+	_, ok = win.hash(big.NewInt(106))
+	assert.False(t, ok)
+
+	// Only the last 16 blocks are kept.
+	chain.extend(106, 120, 2)
+
+	forked, err = win.update(ctx, chain, chain.head())
+	require.NoError(t, err)
+	assert.Nil(t, forked)
+
+	_, ok = win.hash(big.NewInt(104))
+	assert.False(t, ok)
+	_, ok = win.hash(big.NewInt(105))
+	assert.True(t, ok)
+}
@@ -0,0 +1,100 @@
+package ticker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/trace"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+)
+
+// revertData recovers the revert data of a mined transaction that failed. It
+// traces the transaction if the node allows it. Otherwise, it replays the
+// call with eth_call on top of the parent block, which won't see the effects
+// of earlier transactions in the same block, and so may not revert the same
+// way or at all. Failures are logged, since the outcome is known without the
+// data.
+func (w *Watcher) revertData(ctx context.Context, logger *zerolog.Logger, rec *ethtypes.Receipt) []byte {
+	frame, err := trace.Transaction(ctx, w.rpc, rec.TxHash)
+	if err == nil {
+		if frame.Error == "" {
+			return nil
+		}
+		return frame.Output
+	}
+
+	logger.Debug().Err(err).Msg("Couldn't trace failed transaction, replaying it instead.")
+
+	data, err := w.replay(ctx, rec)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to recover revert data.")
+		return nil
+	}
+
+	return data
+}
+
+// replay runs the transaction with eth_call at the parent of its block and
+// returns the revert data, if it reverts.
+func (w *Watcher) replay(ctx context.Context, rec *ethtypes.Receipt) ([]byte, error) {
+	_, err := w.callAtParent(ctx, rec)
+	if err == nil {
+		return nil, errors.New("replayed call succeeded")
+	}
+
+	var jerr ethJSONRPCError
+	if !errors.As(err, &jerr) {
+		return nil, err
+	}
+
+	hexData, ok := jerr.ErrorData().(string)
+	if !ok {
+		return nil, nil
+	}
+
+	return hexutil.Decode(hexData)
+}
+
+// callAtParent runs the transaction with eth_call at the parent of its block
+// and returns the output.
+func (w *Watcher) callAtParent(ctx context.Context, rec *ethtypes.Receipt) ([]byte, error) {
+	tx, _, err := w.client.TransactionByHash(ctx, rec.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transaction %s: %w", rec.TxHash, err)
+	}
+
+	arg := map[string]any{
+		"from":  w.sender.Address(),
+		"gas":   hexutil.Uint64(tx.Gas()),
+		"value": (*hexutil.Big)(tx.Value()),
+		"data":  hexutil.Bytes(tx.Data()),
+	}
+	if tx.To() != nil {
+		arg["to"] = tx.To()
+	}
+
+	parent := new(big.Int).Sub(rec.BlockNumber, common.Big1)
+
+	var out hexutil.Bytes
+	if err := w.rpc.CallContext(ctx, &out, "eth_call", arg, hexutil.EncodeBig(parent)); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// revertReason decodes Error(string) and Panic(uint256) revert data. Custom
+// errors and empty data give the empty string.
+func revertReason(data []byte) string {
+	reason, err := abi.UnpackRevert(data)
+	if err != nil {
+		return ""
+	}
+	return reason
+}
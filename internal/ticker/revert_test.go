@@ -0,0 +1,62 @@
+package ticker
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevertReason(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "error",
+			data:     "0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000012496e73756666696369656e742066756e64730000000000000000000000000000",
+			expected: "Insufficient funds",
+		},
+		{
+			name:     "empty error",
+			data:     "0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000000",
+			expected: "",
+		},
+		{
+			name:     "truncated error",
+			data:     "0x08c379a00000000000000000000000000000000000000000000000000000000000000020",
+			expected: "",
+		},
+		{
+			name:     "overflow panic",
+			data:     "0x4e487b710000000000000000000000000000000000000000000000000000000000000011",
+			expected: "arithmetic underflow or overflow",
+		},
+		{
+			name:     "assert panic",
+			data:     "0x4e487b710000000000000000000000000000000000000000000000000000000000000001",
+			expected: "assert(false)",
+		},
+		{
+			name:     "unknown panic",
+			data:     "0x4e487b710000000000000000000000000000000000000000000000000000000000000099",
+			expected: "unknown panic code: 0x99",
+		},
+		{
+			// OwnableUnauthorizedAccount(address).
+			name:     "custom error",
+			data:     "0x118cdaa7000000000000000000000000f2e391f11cd1609679d03a1ac965b1d0432a7007",
+			expected: "",
+		},
+		{
+			name:     "no data",
+			data:     "0x",
+			expected: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, revertReason(common.FromHex(tc.data)))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package ticker
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DIMO-Network/meta-transaction-processor/internal/models"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/status"
+	"github.com/DIMO-Network/meta-transaction-processor/internal/trace"
+	"github.com/ethereum/go-ethereum"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+var simulationFailuresTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "meta_transaction_processor",
+		Name:      "simulation_failures_total",
+	},
+	[]string{"chain"},
+)
+
+// simulate traces the request's transaction against the pending block, if
+// we're simulating everything or the request has an expectation. Batches and
+// bundles aren't simulated. If the call fails, or falls short of the
+// expectation, the request fails and the second result is false.
+//
+// Not every node can trace calls. When the trace fails, we go with the gas
+// estimate, which has already run the call without it reverting, unless the
+// request has an expectation that we can't check without the trace.
+func (w *Watcher) simulate(ctx context.Context, logger *zerolog.Logger, reqs []*models.MetaTransactionRequest, callMsg ethereum.CallMsg, gasLimit uint64) (*status.Simulation, bool, error) {
+	req := reqs[0]
+	if len(reqs) > 1 || req.UserOpHash.Valid || !w.simulateAll && !req.Expectation.Valid {
+		return nil, true, nil
+	}
+
+	callMsg.Gas = gasLimit
+
+	frame, err := trace.Call(ctx, w.rpc, callMsg, "pending")
+	if err != nil {
+		if !req.Expectation.Valid {
+			logger.Warn().Err(err).Msg("Couldn't trace transaction, relying on the gas estimate.")
+			return nil, true, nil
+		}
+
+		logger.Warn().Err(err).Msg("Couldn't trace transaction to check its expectation, not sending it.")
+		simulationFailuresTotal.With(prometheus.Labels{"chain": w.chainID.String()}).Inc()
+
+		return nil, false, w.failSimulation(ctx, req, nil, "Couldn't trace the call to check the expectation.")
+	}
+
+	checkErr := frame.Err()
+	if checkErr == nil && req.Expectation.Valid {
+		var exp trace.Expectation
+		if err := req.Expectation.Unmarshal(&exp); err != nil {
+			return nil, false, err
+		}
+		checkErr = exp.Check(frame)
+	}
+
+	if checkErr != nil {
+		logger.Warn().Err(checkErr).Msg("Transaction failed in simulation, not sending it.")
+		simulationFailuresTotal.With(prometheus.Labels{"chain": w.chainID.String()}).Inc()
+
+		var data []byte
+		if frame.Error != "" {
+			data = frame.Output
+		}
+
+		return nil, false, w.failSimulation(ctx, req, data, checkErr.Error())
+	}
+
+	logs := frame.AllLogs()
+
+	sim := &status.Simulation{
+		GasUsed: uint64(frame.GasUsed),
+		Calls:   frame.CallCount(),
+		Logs:    make([]*status.Log, len(logs)),
+	}
+
+	for i, l := range logs {
+		sim.Logs[i] = &status.Log{Address: l.Address, Topics: l.Topics, Data: l.Data}
+	}
+
+	logger.Info().Msgf("Simulated transaction used %d gas in %d calls and emitted %d logs.", sim.GasUsed, sim.Calls, len(sim.Logs))
+
+	return sim, true, nil
+}
+
+// failSimulation reports the request as having failed simulation and drops it.
+func (w *Watcher) failSimulation(ctx context.Context, req *models.MetaTransactionRequest, data []byte, message string) error {
+	return w.inTx(ctx, func(dbTx *sql.Tx) error {
+		err := w.prod.Failed(ctx, dbTx, &status.FailedMsg{
+			ID:      req.ID,
+			Data:    data,
+			Reason:  status.ReasonSimulation,
+			Message: message,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = req.Delete(ctx, dbTx)
+		return err
+	})
+}
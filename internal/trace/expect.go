@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Expectation is what a request says its transaction must do.
+type Expectation struct {
+	// Logs must all be emitted, in any order.
+	Logs []*ExpectedLog `json:"logs,omitempty"`
+}
+
+// ExpectedLog matches logs. A missing address matches any contract, and a
+// null topic matches anything in that position. Logs may have more topics
+// than are listed.
+type ExpectedLog struct {
+	Address *common.Address `json:"address,omitempty"`
+	Topics  []*common.Hash  `json:"topics,omitempty"`
+}
+
+// Validate checks that the expectation asks for something.
+func (e *Expectation) Validate() error {
+	if len(e.Logs) == 0 {
+		return errors.New("expectation has no logs")
+	}
+	for i, l := range e.Logs {
+		if l == nil || l.Address == nil && len(l.Topics) == 0 {
+			return fmt.Errorf("expected log %d matches anything", i)
+		}
+	}
+	return nil
+}
+
+func (l *ExpectedLog) matches(log *Log) bool {
+	if l.Address != nil && *l.Address != log.Address {
+		return false
+	}
+	if len(l.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, t := range l.Topics {
+		if t != nil && *t != log.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns an error describing the first way in which the traced call
+// falls short of the expectation.
+func (e *Expectation) Check(f *Frame) error {
+	if err := f.Err(); err != nil {
+		return err
+	}
+
+	logs := f.AllLogs()
+
+	for i, want := range e.Logs {
+		found := false
+		for _, log := range logs {
+			if want.matches(log) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("expected log %d wasn't emitted", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Client is the part of rpc.Client that we need.
+type Client interface {
+	CallContext(ctx context.Context, result any, method string, args ...any) error
+}
+
+// Frame is a call frame from geth's callTracer.
+type Frame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []*Frame        `json:"calls,omitempty"`
+	Logs         []*Log          `json:"logs,omitempty"`
+}
+
+// Log is a log emitted directly by a frame. Logs from reverted frames are
+// dropped by the tracer.
+type Log struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+	// Position is the number of subcalls the frame had made when it emitted
+	// the log.
+	Position hexutil.Uint `json:"position"`
+}
+
+// tracerOptions asks for the callTracer with logs.
+var tracerOptions = map[string]any{
+	"tracer":       "callTracer",
+	"tracerConfig": map[string]any{"withLog": true},
+}
+
+// Transaction traces a mined transaction. The node has to have the debug API
+// enabled.
+func Transaction(ctx context.Context, c Client, hash common.Hash) (*Frame, error) {
+	var f Frame
+	if err := c.CallContext(ctx, &f, "debug_traceTransaction", hash, tracerOptions); err != nil {
+		return nil, fmt.Errorf("failed to trace transaction %s: %w", hash, err)
+	}
+	return &f, nil
+}
+
+// callArgs is the transaction object for debug_traceCall.
+type callArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to,omitempty"`
+	Gas      hexutil.Uint64  `json:"gas,omitempty"`
+	GasPrice *hexutil.Big    `json:"gasPrice,omitempty"`
+	Value    *hexutil.Big    `json:"value,omitempty"`
+	Data     hexutil.Bytes   `json:"data,omitempty"`
+}
+
+// Call traces msg as if it were sent in the given block, which is usually
+// "pending". The node has to have the debug API enabled.
+func Call(ctx context.Context, c Client, msg ethereum.CallMsg, block string) (*Frame, error) {
+	args := callArgs{
+		From:     msg.From,
+		To:       msg.To,
+		Gas:      hexutil.Uint64(msg.Gas),
+		GasPrice: (*hexutil.Big)(msg.GasPrice),
+		Value:    (*hexutil.Big)(msg.Value),
+		Data:     msg.Data,
+	}
+
+	var f Frame
+	if err := c.CallContext(ctx, &f, "debug_traceCall", args, block, tracerOptions); err != nil {
+		return nil, fmt.Errorf("failed to trace call: %w", err)
+	}
+	return &f, nil
+}
+
+// Err returns an error if the call failed, with the revert reason if the
+// tracer could decode one.
+func (f *Frame) Err() error {
+	switch {
+	case f.Error == "":
+		return nil
+	case f.RevertReason != "":
+		return fmt.Errorf("call failed: %s: %s", f.Error, f.RevertReason)
+	default:
+		return fmt.Errorf("call failed: %s", f.Error)
+	}
+}
+
+// CallCount returns the number of frames in the trace, including this one.
+func (f *Frame) CallCount() int {
+	n := 1
+	for _, call := range f.Calls {
+		n += call.CallCount()
+	}
+	return n
+}
+
+// AllLogs returns the logs emitted by the frame and everything it called, in
+// the order in which they were emitted.
+func (f *Frame) AllLogs() []*Log {
+	var out []*Log
+
+	i := 0
+	for pos, call := range f.Calls {
+		for ; i < len(f.Logs) && int(f.Logs[i].Position) <= pos; i++ {
+			out = append(out, f.Logs[i])
+		}
+		out = append(out, call.AllLogs()...)
+	}
+
+	return append(out, f.Logs[i:]...)
+}
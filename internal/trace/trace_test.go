@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllLogs(t *testing.T) {
+	log := func(n byte, pos uint) *Log {
+		return &Log{Address: common.BytesToAddress([]byte{n}), Position: hexutil.Uint(pos)}
+	}
+
+	l1, l2, l3, l4, l5 := log(1, 0), log(2, 0), log(3, 1), log(4, 0), log(5, 2)
+
+	// l1 is emitted before the first call and l3 between the two.
+	f := &Frame{
+		Logs: []*Log{l1, l3, l5},
+		Calls: []*Frame{
+			{Logs: []*Log{l2}},
+			{Calls: []*Frame{{Logs: []*Log{l4}}}},
+		},
+	}
+
+	assert.Equal(t, []*Log{l1, l2, l3, l4, l5}, f.AllLogs())
+}
+
+func TestExpectationCheck(t *testing.T) {
+	token := common.HexToAddress("0x662f3314e5bb2ea8a9c18c80c93e064fdadf18b1")
+	transfer := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	approval := common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+	from := common.HexToHash("0x01")
+
+	f := &Frame{
+		Calls: []*Frame{{
+			Logs: []*Log{{Address: token, Topics: []common.Hash{transfer, from, {}}}},
+		}},
+	}
+
+	tests := []struct {
+		name string
+		want *ExpectedLog
+		ok   bool
+	}{
+		{"Address", &ExpectedLog{Address: &token}, true},
+		{"Topic", &ExpectedLog{Topics: []*common.Hash{&transfer}}, true},
+		{"Wildcard", &ExpectedLog{Address: &token, Topics: []*common.Hash{nil, &from}}, true},
+		{"WrongTopic", &ExpectedLog{Address: &token, Topics: []*common.Hash{&approval}}, false},
+		{"WrongAddress", &ExpectedLog{Address: &common.Address{}, Topics: []*common.Hash{&transfer}}, false},
+		{"TooManyTopics", &ExpectedLog{Topics: []*common.Hash{nil, nil, nil, nil}}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&Expectation{Logs: []*ExpectedLog{tc.want}}).Check(f)
+			if tc.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	reverted := &Frame{Error: "execution reverted"}
+	assert.Error(t, (&Expectation{Logs: []*ExpectedLog{{Address: &token}}}).Check(reverted))
+}
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+CREATE TABLE outbox_events(
+    id bigserial
+        CONSTRAINT outbox_events_id_pkey PRIMARY KEY,
+    request_id text NOT NULL,
+    payload bytea NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+DROP TABLE outbox_events;
+-- +goose StatementEnd
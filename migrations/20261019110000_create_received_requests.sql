@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+CREATE TABLE received_requests(
+    id char(27)
+        CONSTRAINT received_requests_id_pkey PRIMARY KEY,
+    payload_hash bytea NOT NULL
+        CONSTRAINT received_requests_payload_hash_check CHECK (length(payload_hash) = 32),
+    created_at timestamptz NOT NULL DEFAULT current_timestamp
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+DROP TABLE received_requests;
+-- +goose StatementEnd
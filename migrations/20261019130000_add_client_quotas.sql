@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests ADD COLUMN client_id text NOT NULL DEFAULT '';
+
+CREATE TABLE client_rate_windows(
+    client_id text
+        CONSTRAINT client_rate_windows_client_id_pkey PRIMARY KEY,
+    window_start timestamptz NOT NULL,
+    count integer NOT NULL
+);
+
+CREATE TABLE client_gas_spend(
+    client_id text NOT NULL,
+    day date NOT NULL,
+    wei numeric(78) NOT NULL,
+    CONSTRAINT client_gas_spend_pkey PRIMARY KEY (client_id, day)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+DROP TABLE client_gas_spend;
+DROP TABLE client_rate_windows;
+
+ALTER TABLE meta_transaction_requests DROP COLUMN client_id;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests ADD COLUMN priority integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests DROP COLUMN priority;
+-- +goose StatementEnd
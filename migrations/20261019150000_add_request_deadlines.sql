@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN not_before timestamptz,
+    ADD COLUMN expires_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests
+    DROP COLUMN not_before,
+    DROP COLUMN expires_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN value numeric(78),
+    ADD COLUMN gas_limit numeric(20);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests
+    DROP COLUMN value,
+    DROP COLUMN gas_limit;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- A null "to" means the request deploys a contract. The length check still
+-- applies to everything else.
+ALTER TABLE meta_transaction_requests ALTER COLUMN "to" DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests ALTER COLUMN "to" SET NOT NULL;
+-- +goose StatementEnd
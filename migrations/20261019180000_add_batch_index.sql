@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- Requests batched through Multicall3 share a transaction, so they share a hash.
+-- batch_index is the position of the request's call within the batch, and is
+-- null for requests sent on their own.
+ALTER TABLE meta_transaction_requests
+    DROP CONSTRAINT meta_transaction_requests_hash_key,
+    ADD COLUMN batch_index integer;
+
+CREATE INDEX meta_transaction_requests_hash_idx ON meta_transaction_requests (hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+DROP INDEX meta_transaction_requests_hash_idx;
+
+ALTER TABLE meta_transaction_requests
+    DROP COLUMN batch_index,
+    ADD CONSTRAINT meta_transaction_requests_hash_key UNIQUE (hash);
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- Set for ERC-4337 user operations. The data column then holds the encoded
+-- operation, and "to" the EntryPoint.
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN user_op_hash bytea
+        CONSTRAINT meta_transaction_requests_user_op_hash_check CHECK (length(user_op_hash) = 32);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests DROP COLUMN user_op_hash;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- Null for requests received before we served more than one chain. These
+-- belong to the default chain.
+ALTER TABLE meta_transaction_requests ADD COLUMN chain_id bigint;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests DROP COLUMN chain_id;
+-- +goose StatementEnd
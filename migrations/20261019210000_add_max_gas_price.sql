@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- max_gas_price is the request's own ceiling, in wei. delayed_at is set while
+-- the request is waiting for gas prices to come down, so that it only gets one
+-- Delayed message per wait.
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN max_gas_price numeric(78),
+    ADD COLUMN delayed_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests
+    DROP COLUMN max_gas_price,
+    DROP COLUMN delayed_at;
+-- +goose StatementEnd
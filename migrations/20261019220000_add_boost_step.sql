@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- boost_step is the number of escalation steps taken so far. canceled_at is
+-- set once the escalation schedule has replaced the transaction with an empty
+-- one.
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN boost_step integer NOT NULL DEFAULT 0,
+    ADD COLUMN canceled_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests
+    DROP COLUMN boost_step,
+    DROP COLUMN canceled_at;
+-- +goose StatementEnd
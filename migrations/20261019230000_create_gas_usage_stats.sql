@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- Gas used by our confirmed transactions, by chain, contract and function
+-- selector. selector is empty for calls with less than four bytes of data.
+CREATE TABLE gas_usage_stats(
+    chain_id bigint NOT NULL,
+    address bytea NOT NULL,
+    selector bytea NOT NULL,
+    samples bigint NOT NULL,
+    max_gas_used bigint NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT gas_usage_stats_pkey PRIMARY KEY (chain_id, address, selector)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+DROP TABLE gas_usage_stats;
+-- +goose StatementEnd
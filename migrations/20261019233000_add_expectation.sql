@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+-- What the request says its transaction must do, checked by simulating it
+-- before it's sent.
+ALTER TABLE meta_transaction_requests ADD COLUMN expectation jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path TO meta_transaction_processor;
+
+ALTER TABLE meta_transaction_requests DROP COLUMN expectation;
+-- +goose StatementEnd